@@ -0,0 +1,108 @@
+(function () {
+    var entries = JSON.parse(document.getElementById("config-data").textContent) || [];
+    var tree = document.getElementById("tree");
+    var search = document.getElementById("search");
+    var effective = document.getElementById("effective");
+
+    function badge(text, cls) {
+        var span = document.createElement("span");
+        span.className = "badge" + (cls ? " " + cls : "");
+        span.textContent = text;
+        return span;
+    }
+
+    function format(value) {
+        if (value === undefined || value === null) {
+            return "";
+        }
+        return typeof value === "string" ? value : JSON.stringify(value);
+    }
+
+    function group(parent, groups, path, name) {
+        if (!groups[path]) {
+            var details = document.createElement("details");
+            details.open = true;
+            var summary = document.createElement("summary");
+            summary.textContent = name;
+            details.appendChild(summary);
+            parent.appendChild(details);
+            groups[path] = details;
+        }
+        return groups[path];
+    }
+
+    var groups = {};
+    entries.forEach(function (entry) {
+        var segments = entry.key.split(".");
+        var parent = tree;
+        for (var i = 0; i < segments.length - 1; i++) {
+            parent = group(parent, groups, segments.slice(0, i + 1).join("."), segments[i]);
+        }
+        var row = document.createElement("div");
+        row.className = "key";
+        row.dataset.search = [entry.key, entry.type, entry.description || ""].join(" ").toLowerCase();
+
+        var name = document.createElement("span");
+        name.className = "name";
+        name.textContent = segments[segments.length - 1];
+        name.title = entry.key;
+        row.appendChild(name);
+
+        var badges = document.createElement("span");
+        badges.appendChild(badge(entry.type));
+        if (entry.required) {
+            badges.appendChild(badge("required", "required"));
+        }
+        if (entry.sensitive) {
+            badges.appendChild(badge("sensitive", "sensitive"));
+        }
+        if (entry.origin) {
+            badges.appendChild(badge(entry.origin, "origin"));
+        }
+        row.appendChild(badges);
+
+        var value = document.createElement("span");
+        value.className = "value";
+        value.dataset.template = format(entry.template);
+        value.dataset.effective = format(entry.effective);
+        value.textContent = value.dataset.template;
+        row.appendChild(value);
+
+        if (entry.description) {
+            var desc = document.createElement("span");
+            desc.className = "desc";
+            desc.textContent = entry.description;
+            row.appendChild(desc);
+        }
+        parent.appendChild(row);
+    });
+
+    effective.addEventListener("change", function () {
+        tree.querySelectorAll(".value").forEach(function (value) {
+            value.textContent = effective.checked ? value.dataset.effective : value.dataset.template;
+        });
+    });
+
+    search.addEventListener("input", function () {
+        var query = search.value.trim().toLowerCase();
+        tree.querySelectorAll(".key").forEach(function (row) {
+            row.classList.toggle("hidden", query !== "" && row.dataset.search.indexOf(query) === -1);
+        });
+        var details = tree.querySelectorAll("details");
+        for (var i = details.length - 1; i >= 0; i--) {
+            var visible = details[i].querySelector(".key:not(.hidden)") !== null;
+            details[i].classList.toggle("hidden", !visible);
+            if (query !== "" && visible) {
+                details[i].open = true;
+            }
+        }
+    });
+
+    function toggleAll(open) {
+        tree.querySelectorAll("details").forEach(function (details) {
+            details.open = open;
+        });
+    }
+    document.getElementById("expand").addEventListener("click", function () { toggleAll(true); });
+    document.getElementById("collapse").addEventListener("click", function () { toggleAll(false); });
+})();
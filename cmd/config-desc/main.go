@@ -0,0 +1,41 @@
+// Command config-desc generates ConfigDescription methods from the doc
+// comments of the fields of configuration structs, so that the descriptions
+// exported by the AnnotationDescription mode follow the Go sources. Run it
+// from a go:generate directive in the package declaring the structs:
+//
+//	//go:generate go run github.com/go-kid/config-exporter/cmd/config-desc -type CacheConfig,PoolConfig
+//
+// Without -type every struct type with a Prefix method is described.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	config_exporter "github.com/go-kid/config-exporter"
+	"os"
+	"strings"
+)
+
+func main() {
+	fs := flag.NewFlagSet("config-desc", flag.ExitOnError)
+	types := fs.String("type", "", "comma-separated struct types to describe")
+	mapper := fs.String("mapper", "yaml", "struct tag naming the keys")
+	output := fs.String("output", "config_description_gen.go", "file to write")
+	dir := fs.String("dir", ".", "directory of the package")
+	_ = fs.Parse(os.Args[1:])
+
+	src := config_exporter.DescriptionSource{Dir: *dir, Mapper: *mapper}
+	if *types != "" {
+		src.Types = strings.Split(*types, ",")
+	}
+	buf := &bytes.Buffer{}
+	if err := config_exporter.GenerateDescriptions(buf, src); err != nil {
+		fmt.Fprintln(os.Stderr, "config-desc:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, buf.Bytes(), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "config-desc:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,82 @@
+// Command config-diff compares two metadata snapshots written by the
+// "metadata" export format and prints the configuration changes between them.
+// With -check it reports the breaking changes instead and exits with status 1
+// when there are any.
+//
+//	config-diff [-json] [-check] old.json new.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	config_exporter "github.com/go-kid/config-exporter"
+	"os"
+)
+
+func main() {
+	// a dedicated flag set keeps the flags registered by ioc out of the usage
+	fs := flag.NewFlagSet("config-diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the diff as JSON instead of Markdown")
+	check := fs.Bool("check", false, "fail when the changes break configurations of old")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: config-diff [-json] [-check] old new")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[1:])
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	oldEntries, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	newEntries, err := readSnapshot(fs.Arg(1))
+	if err != nil {
+		fatal(err)
+	}
+	if *check {
+		report := config_exporter.CheckCompatibility(oldEntries, newEntries)
+		if *asJSON {
+			printJSON(report)
+		}
+		if err := report.Err(); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	diff := config_exporter.Diff(oldEntries, newEntries)
+	if *asJSON {
+		printJSON(diff)
+		return
+	}
+	fmt.Print(diff.String())
+}
+
+func printJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		fatal(err)
+	}
+}
+
+func readSnapshot(name string) ([]config_exporter.ConfigEntry, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries, err := config_exporter.ReadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return entries, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
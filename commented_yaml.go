@@ -0,0 +1,226 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/strconv2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	FormatCommentedYAML = "commented-yaml"
+
+	annotationModes = AnnotationSource | AnnotationSourceProperty | AnnotationArgs | AnnotationDescription | AnnotationOrigin | AnnotationEnv
+)
+
+func init() {
+	RegisterFormatter(FormatCommentedYAML, FormatterFunc(formatCommentedYAML))
+}
+
+// formatCommentedYAML renders the annotations enabled by mode as comments on
+// the annotated keys rather than as sibling "key@Annotation" keys, so that the
+// output stays a loadable configuration file.
+func formatCommentedYAML(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+	root := &yaml.Node{}
+	if err := root.Encode(exportedConfig(exporter, m&^annotationModes)); err != nil {
+		return errors.Wrap(err, "encode YAML node")
+	}
+	if m.Eq(annotationModes) {
+		if err := commentAnnotations(root, exporter.GetConfig(m), ""); err != nil {
+			return err
+		}
+	}
+	var (
+		entries      = exporter.Describe()
+		placeholders = make(map[string]bool)
+	)
+	for _, entry := range entries {
+		if entry.Origin == OriginPlaceholder || entry.Origin == OriginExample {
+			placeholders[entry.Key] = true
+		}
+		if !entry.HasDefault {
+			continue
+		}
+		keyNode, valueNode := findNode(root, entry.Key)
+		if keyNode == nil {
+			continue
+		}
+		def, err := strconv2.FormatAny(entry.Default)
+		if err != nil {
+			return errors.Wrapf(err, "format default value of '%s'", entry.Key)
+		}
+		if valueNode.Kind == yaml.ScalarNode {
+			valueNode.LineComment = "default: " + def
+		} else {
+			keyNode.LineComment = "default: " + def
+		}
+	}
+	if m.Eq(CommentPlaceholders) && !m.Eq(EffectiveValues) {
+		if err := commentPlaceholders(root, "", placeholders); err != nil {
+			return err
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(4)
+	if err := encoder.Encode(root); err != nil {
+		return errors.Wrap(err, "encode YAML")
+	}
+	return encoder.Close()
+}
+
+func commentAnnotations(root *yaml.Node, annotated map[string]any, path string) error {
+	var keys []string
+	for key := range annotated {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := annotated[key]
+		i := strings.LastIndex(key, "@")
+		if i == -1 {
+			if sub, ok := value.(map[string]any); ok {
+				if err := commentAnnotations(root, sub, joinKey(path, key)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		keyNode, _ := findNode(root, joinKey(path, key[:i]))
+		if keyNode == nil {
+			continue
+		}
+		comment, err := annotationComment(key[i+1:], value)
+		if err != nil {
+			return errors.Wrapf(err, "marshal annotation '%s'", key)
+		}
+		switch {
+		case keyNode.HeadComment == "":
+			keyNode.HeadComment = comment
+		case key[i+1:] == "Description":
+			keyNode.HeadComment = comment + "\n" + keyNode.HeadComment
+		default:
+			keyNode.HeadComment = keyNode.HeadComment + "\n" + comment
+		}
+	}
+	return nil
+}
+
+// commentPlaceholders turns the pairs of mapping holding nothing but
+// placeholders into comments on the previous or next kept key.
+func commentPlaceholders(mapping *yaml.Node, path string, placeholders map[string]bool) error {
+	var (
+		content []*yaml.Node
+		pending []string
+	)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], mapping.Content[i+1]
+		key := joinKey(path, keyNode.Value)
+		if !isPlaceholder(valueNode, key, placeholders) {
+			if valueNode.Kind == yaml.MappingNode {
+				if err := commentPlaceholders(valueNode, key, placeholders); err != nil {
+					return err
+				}
+			}
+			if len(pending) != 0 {
+				keyNode.HeadComment = joinComments(append(pending, keyNode.HeadComment)...)
+				pending = nil
+			}
+			content = append(content, keyNode, valueNode)
+			continue
+		}
+		comment := keyNode.HeadComment
+		keyNode.HeadComment = ""
+		bytes, err := yaml.Marshal(&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{keyNode, valueNode}})
+		if err != nil {
+			return errors.Wrapf(err, "marshal placeholder '%s'", key)
+		}
+		pending = append(pending, joinComments(comment, strings.TrimRight(string(bytes), "\n")))
+	}
+	if len(pending) != 0 {
+		if len(content) == 0 {
+			mapping.HeadComment = joinComments(append([]string{mapping.HeadComment}, pending...)...)
+		} else {
+			last := content[len(content)-2]
+			last.FootComment = joinComments(append([]string{last.FootComment}, pending...)...)
+		}
+	}
+	mapping.Content = content
+	return nil
+}
+
+// isPlaceholder reports whether node is the placeholder of key or a mapping
+// of placeholders only.
+func isPlaceholder(node *yaml.Node, key string, placeholders map[string]bool) bool {
+	if placeholders[key] {
+		return true
+	}
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isPlaceholder(node.Content[i+1], joinKey(key, node.Content[i].Value), placeholders) {
+			return false
+		}
+	}
+	return true
+}
+
+func joinComments(comments ...string) string {
+	var lines []string
+	for _, comment := range comments {
+		if comment != "" {
+			lines = append(lines, comment)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func annotationComment(name string, value any) (string, error) {
+	if desc, ok := value.(string); ok && name == "Description" {
+		return desc, nil
+	}
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	content := strings.TrimRight(string(bytes), "\n")
+	if !strings.Contains(content, "\n") {
+		if _, isMap := value.(map[string]any); !isMap {
+			return fmt.Sprintf("@%s: %s", name, content), nil
+		}
+	}
+	return fmt.Sprintf("@%s:\n    %s", name, strings.ReplaceAll(content, "\n", "\n    ")), nil
+}
+
+// findNode returns the key and value nodes of the dotted key in a mapping node.
+func findNode(node *yaml.Node, key string) (keyNode, valueNode *yaml.Node) {
+	valueNode = node
+	for _, seg := range strings.Split(key, ".") {
+		if valueNode.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		var found bool
+		for i := 0; i+1 < len(valueNode.Content); i += 2 {
+			if valueNode.Content[i].Value == seg {
+				keyNode, valueNode, found = valueNode.Content[i], valueNode.Content[i+1], true
+				break
+			}
+		}
+		if !found {
+			return nil, nil
+		}
+	}
+	return keyNode, valueNode
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
@@ -0,0 +1,57 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/properties"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCommentedYAML(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&DescribedComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = exporter.Export(buf, FormatCommentedYAML, AnnotationSource|AnnotationArgs|AnnotationDescription)
+	assert.NoError(t, err)
+	assert.Equal(t, `app:
+    # application name
+    # @Args:
+    #     Required: true
+    # @Sources: github.com/go-kid/config-exporter/DescribedComponent
+    name: demo # default: demo
+    # @Args:
+    #     Required: true
+    # @Sources: github.com/go-kid/config-exporter/DescribedComponent
+    raw: string
+# local cache settings
+# @Args:
+#     Required: true
+cache:
+    # max entries kept in memory
+    # @Sources: github.com/go-kid/config-exporter/DescribedComponent
+    size: 0
+    sub:
+        # nested key
+        # @Sources: github.com/go-kid/config-exporter/DescribedComponent
+        sub: string
+    # entry time to live in seconds
+    # @Sources: github.com/go-kid/config-exporter/DescribedComponent
+    ttl: 0
+`, buf.String(), buf.String())
+
+	loaded := properties.New()
+	assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &loaded))
+	expected, err := yaml.Marshal(exporter.GetConfig(0))
+	assert.NoError(t, err)
+	actual, err := yaml.Marshal(loaded)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -0,0 +1,211 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type CompatibilityReport struct {
+	Breaking    []ConfigChange `json:"breaking,omitempty" yaml:"breaking,omitempty"`
+	NonBreaking []ConfigChange `json:"nonBreaking,omitempty" yaml:"nonBreaking,omitempty"`
+}
+
+// CheckCompatibility classifies the changes between two metadata snapshots.
+// A change is breaking when a configuration accepted by old may be rejected
+// by new: a removed key, a newly required key without default, a narrowed
+// type or a tightened validate rule.
+func CheckCompatibility(old, new []ConfigEntry) *CompatibilityReport {
+	var (
+		report   = &CompatibilityReport{}
+		diff     = Diff(old, new)
+		oldByKey = make(map[string]ConfigEntry, len(old))
+		bindings = make(map[string]bool, len(old))
+	)
+	for _, entry := range old {
+		oldByKey[entry.Key] = entry
+		bindings[bindingKey(entry)] = true
+	}
+	for _, entry := range diff.Removed {
+		report.Breaking = append(report.Breaking, ConfigChange{Key: entry.Key, Kind: ChangeRemoved, Reason: "key removed"})
+	}
+	for _, entry := range diff.Added {
+		change := ConfigChange{Key: entry.Key, Kind: ChangeAdded, New: entry.Type, Reason: "optional key added"}
+		// a key added to the struct of an existing prefix is only required
+		// along with the prefix, which configurations accepted by old set
+		if entry.Required && !entry.HasDefault && (entry.Key == bindingKey(entry) || !bindings[bindingKey(entry)]) {
+			change.Reason = "required key added without default"
+			report.Breaking = append(report.Breaking, change)
+		} else {
+			report.NonBreaking = append(report.NonBreaking, change)
+		}
+	}
+	for _, entry := range new {
+		if o, ok := oldByKey[entry.Key]; ok && !o.Required && entry.Required && !entry.HasDefault {
+			report.Breaking = append(report.Breaking, ConfigChange{Key: entry.Key, Kind: ChangeRequired, Old: false, New: true, Reason: "key became required"})
+		}
+	}
+	for _, change := range diff.Changed {
+		switch change.Kind {
+		case ChangeType:
+			oldType, newType := change.Old.(string), change.New.(string)
+			if widens(oldType, newType) {
+				change.Reason = "type widened"
+				report.NonBreaking = append(report.NonBreaking, change)
+			} else {
+				change.Reason = fmt.Sprintf("type narrowed from %s to %s", oldType, newType)
+				report.Breaking = append(report.Breaking, change)
+			}
+		case ChangeValidate:
+			oldRules, _ := change.Old.([]string)
+			newRules, _ := change.New.([]string)
+			if reasons := tightenedRules(oldRules, newRules); len(reasons) != 0 {
+				change.Reason = strings.Join(reasons, ", ")
+				report.Breaking = append(report.Breaking, change)
+			} else {
+				change.Reason = "validate rules loosened"
+				report.NonBreaking = append(report.NonBreaking, change)
+			}
+		default:
+			change.Reason = change.Kind + " changed"
+			report.NonBreaking = append(report.NonBreaking, change)
+		}
+	}
+	sort.SliceStable(report.Breaking, func(i, j int) bool { return report.Breaking[i].Key < report.Breaking[j].Key })
+	sort.SliceStable(report.NonBreaking, func(i, j int) bool { return report.NonBreaking[i].Key < report.NonBreaking[j].Key })
+	return report
+}
+
+// bindingKey returns the binding of entry, or its key for entries built
+// without one.
+func bindingKey(entry ConfigEntry) string {
+	if entry.Binding == "" {
+		return entry.Key
+	}
+	return entry.Binding
+}
+
+// Err returns an error listing the breaking changes, or nil when there are
+// none.
+func (r *CompatibilityReport) Err() error {
+	if len(r.Breaking) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, change := range r.Breaking {
+		lines = append(lines, fmt.Sprintf("%s: %s", change.Key, change.Reason))
+	}
+	return errors.Errorf("%d breaking configuration change(s):\n\t%s", len(lines), strings.Join(lines, "\n\t"))
+}
+
+var (
+	signedRanks   = map[string]int{"int8": 1, "int16": 2, "int32": 3, "int64": 4, "int": 4}
+	unsignedRanks = map[string]int{"uint8": 1, "uint16": 2, "uint32": 3, "uint64": 4, "uint": 4}
+	floatRanks    = map[string]int{"float32": 3, "float64": 4}
+	scalarTypes   = map[string]bool{"bool": true, "string": true, "time.Duration": true}
+)
+
+// widens reports whether every value decodable into oldType is decodable
+// into newType. Values are decoded weakly typed, so strings accept scalars.
+func widens(oldType, newType string) bool {
+	if oldType == newType {
+		return true
+	}
+	if strings.HasPrefix(oldType, "[]") && strings.HasPrefix(newType, "[]") {
+		return widens(oldType[2:], newType[2:])
+	}
+	if newType == "string" {
+		_, signed := signedRanks[oldType]
+		_, unsigned := unsignedRanks[oldType]
+		_, float := floatRanks[oldType]
+		return scalarTypes[oldType] || signed || unsigned || float
+	}
+	if rank, ok := signedRanks[oldType]; ok {
+		if newRank, ok := signedRanks[newType]; ok {
+			return newRank >= rank
+		}
+		newRank, ok := floatRanks[newType]
+		return ok && newRank > rank
+	}
+	if rank, ok := unsignedRanks[oldType]; ok {
+		if newRank, ok := unsignedRanks[newType]; ok {
+			return newRank >= rank
+		}
+		if newRank, ok := signedRanks[newType]; ok {
+			return newRank > rank
+		}
+		newRank, ok := floatRanks[newType]
+		return ok && newRank > rank
+	}
+	if rank, ok := floatRanks[oldType]; ok {
+		newRank, ok := floatRanks[newType]
+		return ok && newRank >= rank
+	}
+	return false
+}
+
+// tightenedRules describes the rules of newRules that reject values accepted
+// by oldRules. Unknown rules are considered tightened when added or changed.
+func tightenedRules(oldRules, newRules []string) []string {
+	var (
+		reasons   []string
+		oldByName = make(map[string]string)
+		newByName = make(map[string]string)
+	)
+	for _, rule := range oldRules {
+		name, param, _ := strings.Cut(rule, "=")
+		oldByName[name] = param
+	}
+	for _, rule := range newRules {
+		name, param, _ := strings.Cut(rule, "=")
+		newByName[name] = param
+	}
+	if _, ok := oldByName["omitempty"]; ok {
+		if _, ok := newByName["omitempty"]; !ok {
+			reasons = append(reasons, "rule 'omitempty' removed")
+		}
+	}
+	for _, rule := range newRules {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "omitempty" {
+			continue
+		}
+		oldParam, ok := oldByName[name]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("rule '%s' added", rule))
+			continue
+		}
+		if oldParam == param || !tightened(name, oldParam, param) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("rule '%s' tightened from '%s=%s'", rule, name, oldParam))
+	}
+	return reasons
+}
+
+func tightened(name, oldParam, newParam string) bool {
+	if name == "oneof" {
+		allowed := strings.Fields(newParam)
+		for _, v := range strings.Fields(oldParam) {
+			if !contains(allowed, v) {
+				return true
+			}
+		}
+		return false
+	}
+	oldN, oldErr := strconv.ParseFloat(oldParam, 64)
+	newN, newErr := strconv.ParseFloat(newParam, 64)
+	if oldErr != nil || newErr != nil {
+		return true
+	}
+	switch name {
+	case "min", "gte", "gt":
+		return newN > oldN
+	case "max", "lte", "lt":
+		return newN < oldN
+	default:
+		return true
+	}
+}
@@ -0,0 +1,113 @@
+package config_exporter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ModeV1 struct {
+	Mode string `prop:"db.mode:rw,validate=oneof=rw ro"`
+}
+
+type ModeV2 struct {
+	Mode string `prop:"db.mode:rw,validate=oneof=rw ro wo"`
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Run("Releases", func(t *testing.T) {
+		report := CheckCompatibility(snapshot(t, &ReleaseV1{}), snapshot(t, &ReleaseV2{}))
+		assert.Equal(t, []ConfigChange{
+			{Key: "server.mode", Kind: ChangeRemoved, Reason: "key removed"},
+			{Key: "server.port", Kind: ChangeValidate, Old: []string{"min=1"}, New: []string{"min=1", "max=65535"}, Reason: "rule 'max=65535' added"},
+			{Key: "server.workers", Kind: ChangeType, Old: "int", New: "float64", Reason: "type narrowed from int to float64"},
+		}, report.Breaking)
+		assert.Equal(t, []ConfigChange{
+			{Key: "server.debug", Kind: ChangeAdded, New: "bool", Reason: "optional key added"},
+			{Key: "server.host", Kind: ChangeDefault, Old: "localhost", New: "0.0.0.0", Reason: "default changed"},
+		}, report.NonBreaking)
+		assert.EqualError(t, report.Err(), "3 breaking configuration change(s):\n"+
+			"\tserver.mode: key removed\n"+
+			"\tserver.port: rule 'max=65535' added\n"+
+			"\tserver.workers: type narrowed from int to float64")
+	})
+	t.Run("Breaking", func(t *testing.T) {
+		old := []ConfigEntry{
+			{Key: "a", Type: "int64"},
+			{Key: "b", Type: "string", Validate: []string{"omitempty", "min=1"}},
+			{Key: "c", Type: "int", Validate: []string{"oneof=1 2 3"}},
+		}
+		report := CheckCompatibility(old, []ConfigEntry{
+			{Key: "a", Type: "int32", Required: true},
+			{Key: "b", Type: "string", Validate: []string{"min=2"}},
+			{Key: "c", Type: "int", Validate: []string{"oneof=1 2"}},
+			{Key: "d", Type: "string", Required: true},
+		})
+		var reasons []string
+		for _, change := range report.Breaking {
+			reasons = append(reasons, change.Key+": "+change.Reason)
+		}
+		assert.Equal(t, []string{
+			"a: key became required",
+			"a: type narrowed from int64 to int32",
+			"b: rule 'omitempty' removed, rule 'min=2' tightened from 'min=1'",
+			"c: rule 'oneof=1 2' tightened from 'oneof=1 2 3'",
+			"d: required key added without default",
+		}, reasons)
+		assert.Empty(t, report.NonBreaking)
+		assert.Error(t, report.Err())
+	})
+	t.Run("Compatible", func(t *testing.T) {
+		report := CheckCompatibility(
+			[]ConfigEntry{{Key: "a", Type: "int32", Validate: []string{"max=10"}}},
+			[]ConfigEntry{{Key: "a", Type: "int64", Validate: []string{"max=20"}}, {Key: "b", Type: "int", Required: true, HasDefault: true, Default: 1}},
+		)
+		assert.Empty(t, report.Breaking)
+		assert.Len(t, report.NonBreaking, 3)
+		assert.NoError(t, report.Err())
+	})
+	t.Run("OneofValueAdded", func(t *testing.T) {
+		report := CheckCompatibility(snapshot(t, &ModeV1{}), snapshot(t, &ModeV2{}))
+		assert.Empty(t, report.Breaking)
+		assert.Equal(t, []ConfigChange{
+			{Key: "db.mode", Kind: ChangeValidate, Old: []string{"oneof=rw ro"}, New: []string{"oneof=rw ro wo"}, Reason: "validate rules loosened"},
+		}, report.NonBreaking)
+	})
+	t.Run("PrefixLeafAdded", func(t *testing.T) {
+		report := CheckCompatibility(
+			[]ConfigEntry{{Key: "svc.host", Binding: "svc", Type: "string", Required: true}},
+			[]ConfigEntry{
+				{Key: "svc.host", Binding: "svc", Type: "string", Required: true},
+				{Key: "svc.port", Binding: "svc", Type: "int", Required: true},
+				{Key: "cache.size", Binding: "cache", Type: "int", Required: true},
+			},
+		)
+		assert.Equal(t, []ConfigChange{
+			{Key: "cache.size", Kind: ChangeAdded, New: "int", Reason: "required key added without default"},
+		}, report.Breaking)
+		assert.Equal(t, []ConfigChange{
+			{Key: "svc.port", Kind: ChangeAdded, New: "int", Reason: "optional key added"},
+		}, report.NonBreaking)
+	})
+}
+
+func TestWidens(t *testing.T) {
+	for _, c := range []struct {
+		old, new string
+		want     bool
+	}{
+		{"int", "int", true},
+		{"int32", "int64", true},
+		{"int64", "int32", false},
+		{"uint32", "int64", true},
+		{"uint64", "int64", false},
+		{"int32", "float64", true},
+		{"float64", "float32", false},
+		{"bool", "string", true},
+		{"string", "int", false},
+		{"[]int32", "[]int64", true},
+		{"[]string", "string", false},
+		{"map[string]int", "map[string]string", false},
+	} {
+		assert.Equal(t, c.want, widens(c.old, c.new), c.old+" -> "+c.new)
+	}
+}
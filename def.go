@@ -4,11 +4,16 @@ import (
 	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/util/mode"
 	"github.com/go-kid/properties"
+	"io"
 )
 
 type ConfigExporter interface {
 	GetConfig(mode mode.Mode) properties.Properties
 	ForEachConfiguration(f Iterator)
+	Export(w io.Writer, format string, mode mode.Mode) error
+	Describe() []ConfigEntry
+	JSONSchema() *JSONSchema
+	UnusedKeys() []UnusedKey
 }
 
 type Iterator func(property *component_definition.Property, prefix string, val any)
@@ -18,4 +23,13 @@ const (
 	AnnotationSource         = mode.M3
 	AnnotationSourceProperty = mode.M4
 	AnnotationArgs           = mode.M5
+	AnnotationDescription    = mode.M6
+	RevealSensitive          = mode.M7
+	EffectiveValues          = mode.M8
+	AnnotationOrigin         = mode.M9
+	AnnotationEnv            = mode.M10
+	PreserveOrder            = mode.M11
+	TypedPlaceholders        = mode.M12
+	ExamplePlaceholders      = mode.M13
+	CommentPlaceholders      = mode.M14
 )
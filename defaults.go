@@ -0,0 +1,27 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc/component_definition"
+	"reflect"
+)
+
+// ConfigDefaults can be implemented by configuration structs to return an
+// instance holding the defaults the application falls back to for the keys
+// left unset. Its non-zero values are exported and described as the defaults
+// of the keys, as `${key:default}` does for value tags. Unlike those, ioc
+// doesn't apply them: a configured prefix is decoded into a new struct, so
+// the application has to apply them itself, e.g. in AfterPropertiesSet, and
+// the effective values report what it was given.
+type ConfigDefaults interface {
+	ConfigDefaults() any
+}
+
+var configDefaultsType = reflect.TypeOf((*ConfigDefaults)(nil)).Elem()
+
+// structDefaultOf returns the default of key given by the ConfigDefaults
+// method of the types on its path.
+func structDefaultOf(property *component_definition.Property, key string) (any, bool) {
+	return nestedValueOf(property, key, "", "", configDefaultsType, func(v reflect.Value) any {
+		return v.Interface().(ConfigDefaults).ConfigDefaults()
+	})
+}
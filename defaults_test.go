@@ -0,0 +1,86 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type RetryConfig struct {
+	Attempts int           `yaml:"attempts"`
+	Backoff  time.Duration `yaml:"backoff"`
+	Codes    []int         `yaml:"codes"`
+	Jitter   bool          `yaml:"jitter"`
+	Policy   string        `yaml:"policy"`
+}
+
+func NewRetryConfig() *RetryConfig {
+	return &RetryConfig{Attempts: 3, Backoff: 250 * time.Millisecond, Codes: []int{502, 503}, Jitter: true}
+}
+
+func (c *RetryConfig) Prefix() string {
+	return "retry"
+}
+
+func (c *RetryConfig) ConfigDefaults() any {
+	return NewRetryConfig()
+}
+
+type DefaultsComponent struct {
+	Retry *RetryConfig
+}
+
+func TestConfigDefaults(t *testing.T) {
+	exporter, err := Export(app.SetComponents(&DefaultsComponent{Retry: NewRetryConfig()}))
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = exporter.Export(buf, FormatCommentedYAML, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, `retry:
+    attempts: 3 # default: 3
+    backoff: 250ms # default: 250ms
+    codes: # default: [502,503]
+        - 502
+        - 503
+    jitter: true # default: true
+    policy: string
+`, buf.String(), buf.String())
+
+	t.Run("Describe", func(t *testing.T) {
+		entries := exporter.Describe()
+		entry := findEntry(entries, "retry.attempts")
+		assert.NotNil(t, entry)
+		assert.True(t, entry.HasDefault)
+		assert.Equal(t, 3, entry.Default)
+		assert.Equal(t, OriginDefault, entry.Origin)
+		entry = findEntry(entries, "retry.policy")
+		assert.False(t, entry.HasDefault)
+		assert.Equal(t, OriginPlaceholder, entry.Origin)
+	})
+
+	t.Run("Loaded", func(t *testing.T) {
+		exporter, err := Export(
+			app.SetComponents(&DefaultsComponent{}),
+			app.SetConfigLoader(loader.NewRawLoader([]byte(`
+retry:
+    attempts: 5
+`))),
+		)
+		assert.NoError(t, err)
+		config := exporter.GetConfig(0)
+		value, _ := config.Get("retry.attempts")
+		assert.EqualValues(t, 5, value)
+		value, _ = config.Get("retry.backoff")
+		assert.Equal(t, 250*time.Millisecond, value)
+
+		// ioc decodes the configured prefix into a new struct, leaving the
+		// defaults to the application
+		entry := findEntry(exporter.Describe(), "retry.backoff")
+		assert.Equal(t, 250*time.Millisecond, entry.Default)
+		assert.Equal(t, time.Duration(0), entry.Effective)
+	})
+}
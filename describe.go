@@ -0,0 +1,290 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/container/processors"
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/ioc/util/el"
+	"github.com/go-kid/properties"
+	"github.com/go-kid/strconv2"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigEntry describes a single configuration key bound by the components
+// of an application.
+type ConfigEntry struct {
+	// Key is the full dotted path of the key, e.g. "Merge.SubP2.sub".
+	Key string `json:"key" yaml:"key"`
+	// Type is the Go type the key is decoded into, e.g. "[]string".
+	Type string `json:"type" yaml:"type"`
+	// Kind is the reflect kind of Type with pointers dereferenced.
+	Kind string `json:"kind" yaml:"kind"`
+	// Default is the value given by a `${key:default}` tag.
+	Default    any  `json:"default,omitempty" yaml:"default,omitempty"`
+	HasDefault bool `json:"hasDefault,omitempty" yaml:"hasDefault,omitempty"`
+	// Value is the value of the key in the loaded configuration.
+	Value  any  `json:"value,omitempty" yaml:"value,omitempty"`
+	Loaded bool `json:"loaded,omitempty" yaml:"loaded,omitempty"`
+	// Effective is the value the application receives at runtime: the loaded
+	// value, the tag default, or the value preset on the component.
+	Effective any `json:"effective,omitempty" yaml:"effective,omitempty"`
+	// Example is given by an `example` tag or a ConfigExample method.
+	Example any `json:"example,omitempty" yaml:"example,omitempty"`
+	// Origin tells where Value comes from: a config loader, the tag default,
+	// the example or the zero-value placeholder.
+	Origin string `json:"origin,omitempty" yaml:"origin,omitempty"`
+	// Env is the environment variable named by the EnvNamer of the exporter.
+	Env string `json:"env,omitempty" yaml:"env,omitempty"`
+	// Binding is the path the consuming property is bound to. It equals Key
+	// for value tags and is the prefix for keys of prefix-bound structs.
+	Binding string `json:"binding" yaml:"binding"`
+	// Tag is the tag the consuming property is declared with, "value" or "prefix".
+	Tag string `json:"tag" yaml:"tag"`
+	// Required reports whether the application fails to start when Binding
+	// is absent from the configuration.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Components lists the components (and embedded structs) consuming the key.
+	Components []string `json:"components,omitempty" yaml:"components,omitempty"`
+	Validate   []string `json:"validate,omitempty" yaml:"validate,omitempty"`
+	Mapper     string   `json:"mapper,omitempty" yaml:"mapper,omitempty"`
+	// Sensitive keys have Default and Value masked.
+	Sensitive bool `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+	// Description is given by a `desc` tag or a ConfigDescription method.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	goType reflect.Type
+}
+
+var quoteHelper = el.NewQuote()
+
+func (d *postProcessor) Describe() []ConfigEntry {
+	var (
+		keys    []string
+		entries = make(map[string]*ConfigEntry)
+	)
+	d.ForEachConfiguration(func(property *component_definition.Property, key string, value any) {
+		entry, ok := entries[key]
+		if !ok {
+			entry = d.newConfigEntry(property, key, value)
+			entries[key] = entry
+			keys = append(keys, key)
+		}
+		if entry.Description == "" {
+			entry.Description = descriptionOf(property, key)
+		}
+		if source := property.Holder.String(); !contains(entry.Components, source) {
+			entry.Components = append(entry.Components, source)
+		}
+	})
+	d.forEachConfiguration(true, func(property *component_definition.Property, key string, value any) {
+		if entry, ok := entries[key]; ok && entry.Effective == nil {
+			if entry.Sensitive && value != nil {
+				value = MaskedValue
+			}
+			entry.Effective = value
+		}
+	})
+	sort.Strings(keys)
+	result := make([]ConfigEntry, len(keys))
+	for i, key := range keys {
+		result[i] = *entries[key]
+	}
+	return result
+}
+
+// joinRules rejoins the values of oneof rules, which ioc splits on spaces
+// like the rules themselves, e.g. ["oneof=rw", "ro"] into ["oneof=rw ro"].
+func joinRules(args []string) []string {
+	var rules []string
+	for _, arg := range args {
+		if n := len(rules); n != 0 && !strings.Contains(arg, "=") && strings.HasPrefix(rules[n-1], "oneof=") {
+			rules[n-1] += " " + arg
+			continue
+		}
+		rules = append(rules, arg)
+	}
+	return rules
+}
+
+func (d *postProcessor) newConfigEntry(property *component_definition.Property, key string, value any) *ConfigEntry {
+	entry := &ConfigEntry{
+		Key:     key,
+		Binding: bindingOf(property, key),
+		Tag:     property.Tag,
+		Mapper:  mapperOf(property),
+	}
+	if validate, ok := property.Args().Find(processors.ArgValidate); ok {
+		entry.Validate = joinRules(validate)
+	}
+
+	entry.goType = keyType(property, key)
+	if entry.goType == nil && value != nil {
+		entry.goType = reflect.TypeOf(value)
+	}
+	if entry.goType != nil {
+		entry.Type = entry.goType.String()
+		entry.Kind = indirectType(entry.goType).Kind().String()
+	}
+
+	_, bindingHasDefault := tagDefaults(property)[entry.Binding]
+	entry.Default, entry.HasDefault = defaultOf(property, entry.Binding, key)
+	if d.configure != nil {
+		if loaded := d.configure.Get(key); loaded != nil {
+			entry.Value, entry.Loaded = loaded, true
+		}
+	}
+	entry.Example, _ = exampleOf(property, key)
+	entry.Origin = d.originOf(property, key, 0)
+	entry.Env = d.envNamer().EnvName(key)
+	entry.Required = property.IsRequired() && !bindingHasDefault
+	if entry.Sensitive = isSensitive(property, key); entry.Sensitive {
+		if entry.HasDefault {
+			entry.Default = MaskedValue
+		}
+		if entry.Example != nil {
+			entry.Example = MaskedValue
+		}
+		if entry.Loaded {
+			entry.Value = MaskedValue
+		}
+	}
+	return entry
+}
+
+func mapperOf(property *component_definition.Property) string {
+	if mappers, ok := property.Args().Find("mapper"); ok && len(mappers) != 0 {
+		return mappers[0]
+	}
+	return "yaml"
+}
+
+func bindingOf(property *component_definition.Property, key string) string {
+	if property.Tag == definition.PrefixTag {
+		return property.TagVal
+	}
+	var binding string
+	for p := range property.Configurations {
+		if (p == key || strings.HasPrefix(key, p+".")) && len(p) > len(binding) {
+			binding = p
+		}
+	}
+	if binding == "" {
+		return key
+	}
+	return binding
+}
+
+// defaultOf returns the tag default of key or else its struct default.
+func defaultOf(property *component_definition.Property, binding, key string) (any, bool) {
+	if def, ok := tagDefaultOf(property, binding, key); ok {
+		return def, true
+	}
+	return structDefaultOf(property, key)
+}
+
+// tagDefaultOf returns the tag default of key, looking it up inside the
+// default of binding when key is nested in it.
+func tagDefaultOf(property *component_definition.Property, binding, key string) (any, bool) {
+	def, ok := tagDefaults(property)[binding]
+	if !ok || binding == key {
+		return def, ok
+	}
+	if m, ok := def.(map[string]any); ok {
+		return properties.Properties(m).Get(strings.TrimPrefix(key, binding+"."))
+	}
+	return nil, false
+}
+
+// tagDefaults parses the default values of all `${key:default}` quotes in
+// the tag of a value property.
+func tagDefaults(property *component_definition.Property) map[string]any {
+	if property.Tag == definition.PrefixTag {
+		return nil
+	}
+	var defaults = make(map[string]any)
+	for _, content := range quoteHelper.FindAllContent(property.TagStr) {
+		spExp := strings.SplitN(content, ":", 2)
+		if len(spExp) != 2 || spExp[1] == "" {
+			continue
+		}
+		parsed, err := strconv2.ParseAny(spExp[1])
+		if err != nil {
+			continue
+		}
+		defaults[spExp[0]] = parsed
+	}
+	return defaults
+}
+
+// keyType returns the Go type key is decoded into, or nil when it can't be
+// resolved from the type of property.
+func keyType(property *component_definition.Property, key string) reflect.Type {
+	var (
+		binding = bindingOf(property, key)
+		rel     []string
+	)
+	if binding != key {
+		rel = strings.Split(strings.TrimPrefix(key, binding+"."), ".")
+	}
+	return resolveType(property.Type, rel, mapperOf(property))
+}
+
+// resolveType walks path through the fields of t the same way mapstructure
+// matches them, returning nil when path can't be resolved.
+func resolveType(t reflect.Type, path []string, mapper string) reflect.Type {
+	for _, seg := range path {
+		t = indirectType(t)
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := findField(t, seg, mapper)
+			if !ok {
+				return nil
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil
+		}
+	}
+	return t
+}
+
+func findField(t reflect.Type, name, mapper string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if strings.EqualFold(fieldName(field, mapper), name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func fieldName(field reflect.StructField, mapper string) string {
+	tag := strings.Split(field.Tag.Get(mapper), ",")[0]
+	if tag == "" {
+		return field.Name
+	}
+	return tag
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func contains(arr []string, s string) bool {
+	for _, a := range arr {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
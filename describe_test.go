@@ -0,0 +1,88 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func findEntry(entries []ConfigEntry, key string) *ConfigEntry {
+	for i := range entries {
+		if entries[i].Key == key {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func TestDescribe(t *testing.T) {
+	type A2 struct {
+		Config *Config
+	}
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&A{}, &A2{}, exporter),
+		app.AddConfigLoader(loader.NewRawLoader([]byte(`
+app:
+    configA: cfgA
+Merge:
+    Sub:
+        sub: sub sub
+`))),
+	)
+	assert.NoError(t, err)
+	entries := exporter.Describe()
+	assert.Len(t, entries, 26)
+
+	t.Run("PrefixKey", func(t *testing.T) {
+		entry := findEntry(entries, "Demo.M")
+		assert.NotNil(t, entry)
+		assert.Equal(t, "map[string]int", entry.Type)
+		assert.Equal(t, "map", entry.Kind)
+		assert.Equal(t, "Demo", entry.Binding)
+		assert.Equal(t, "prefix", entry.Tag)
+		assert.True(t, entry.Required)
+		assert.False(t, entry.Loaded)
+		assert.Equal(t, []string{
+			"github.com/go-kid/config-exporter/A",
+			"github.com/go-kid/config-exporter/A2",
+		}, entry.Components)
+	})
+	t.Run("NestedPrefixKey", func(t *testing.T) {
+		entry := findEntry(entries, "Merge.Sub.sub")
+		assert.NotNil(t, entry)
+		assert.Equal(t, "string", entry.Type)
+		assert.Equal(t, "sub sub", entry.Value)
+		assert.True(t, entry.Loaded)
+		assert.Equal(t, "yaml", entry.Mapper)
+	})
+	t.Run("ValueKeyWithDefault", func(t *testing.T) {
+		entry := findEntry(entries, "app.configSlice")
+		assert.NotNil(t, entry)
+		assert.Equal(t, "[]string", entry.Type)
+		assert.Equal(t, "slice", entry.Kind)
+		assert.Equal(t, []any{"a", "b"}, entry.Default)
+		assert.True(t, entry.HasDefault)
+		assert.False(t, entry.Required)
+		assert.Equal(t, []string{"min=1", "max=10", "required"}, entry.Validate)
+		assert.Equal(t, []string{"github.com/go-kid/config-exporter/A"}, entry.Components)
+	})
+	t.Run("ValueKeyWithoutDefault", func(t *testing.T) {
+		entry := findEntry(entries, "app.configA")
+		assert.NotNil(t, entry)
+		assert.Equal(t, "cfgA", entry.Value)
+		assert.False(t, entry.HasDefault)
+		assert.True(t, entry.Required)
+	})
+	t.Run("ValueKeyOfStruct", func(t *testing.T) {
+		entry := findEntry(entries, "Merge.SubP2")
+		assert.NotNil(t, entry)
+		assert.Equal(t, "*config_exporter.SubConfig", entry.Type)
+		assert.Equal(t, "struct", entry.Kind)
+		assert.Equal(t, map[string]any{"sub": "sub"}, entry.Default)
+		assert.Equal(t, []string{"github.com/go-kid/config-exporter/A.Embed(MergeParent)"}, entry.Components)
+	})
+}
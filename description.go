@@ -0,0 +1,57 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc/component_definition"
+	"reflect"
+	"strings"
+)
+
+const DescriptionTag = "desc"
+
+// ConfigDescription can be implemented by configuration structs to describe
+// their keys, relative to the struct itself, e.g. "SubP2.sub".
+type ConfigDescription interface {
+	ConfigDescription() map[string]string
+}
+
+var configDescriptionType = reflect.TypeOf((*ConfigDescription)(nil)).Elem()
+
+func descriptionOf(property *component_definition.Property, key string) string {
+	binding := bindingOf(property, key)
+	if binding == key {
+		return property.StructField.Tag.Get(DescriptionTag)
+	}
+	var (
+		rel  = strings.Split(strings.TrimPrefix(key, binding+"."), ".")
+		t    = property.Type
+		desc string
+	)
+	for i, seg := range rel {
+		if d, ok := methodDescription(t, strings.Join(rel[i:], ".")); ok {
+			return d
+		}
+		t = indirectType(t)
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := findField(t, seg, mapperOf(property))
+			if !ok {
+				return ""
+			}
+			desc, t = field.Tag.Get(DescriptionTag), field.Type
+		case reflect.Map:
+			desc, t = "", t.Elem()
+		default:
+			return ""
+		}
+	}
+	return desc
+}
+
+func methodDescription(t reflect.Type, rel string) (string, bool) {
+	v, ok := newImplementation(t, configDescriptionType)
+	if !ok {
+		return "", false
+	}
+	desc, ok := v.Interface().(ConfigDescription).ConfigDescription()[rel]
+	return desc, ok
+}
@@ -0,0 +1,61 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type CacheConfig struct {
+	TTL  int       `yaml:"ttl" desc:"entry time to live in seconds"`
+	Size int       `yaml:"size"`
+	Sub  SubConfig `yaml:"sub"`
+}
+
+func (c *CacheConfig) Prefix() string {
+	return "cache"
+}
+
+func (c *CacheConfig) ConfigDescription() map[string]string {
+	return map[string]string{
+		"size":    "max entries kept in memory",
+		"sub.sub": "nested key",
+	}
+}
+
+type DescribedComponent struct {
+	Cache *CacheConfig `desc:"local cache settings"`
+	Name  string       `prop:"app.name:demo" desc:"application name"`
+	Raw   string       `prop:"app.raw"`
+}
+
+func TestAnnotationDescription(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&DescribedComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+	bytes, err := yaml.Marshal(exporter.GetConfig(AnnotationDescription))
+	assert.NoError(t, err)
+	assert.Equal(t, `app:
+    name: demo
+    name@Description: application name
+    raw: string
+cache:
+    size: 0
+    size@Description: max entries kept in memory
+    sub:
+        sub: string
+        sub@Description: nested key
+    ttl: 0
+    ttl@Description: entry time to live in seconds
+cache@Description: local cache settings
+`, string(bytes), string(bytes))
+
+	entry := findEntry(exporter.Describe(), "cache.ttl")
+	assert.NotNil(t, entry)
+	assert.Equal(t, "entry time to live in seconds", entry.Description)
+}
@@ -0,0 +1,167 @@
+package config_exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/strconv2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FormatMetadata writes the Describe entries as JSON, a snapshot that can be
+// compared with Diff after being read back by ReadSnapshot.
+const FormatMetadata = "metadata"
+
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeRequired = "required"
+	ChangeDefault  = "default"
+	ChangeType     = "type"
+	ChangeValidate = "validate"
+)
+
+func init() {
+	RegisterFormatter(FormatMetadata, FormatterFunc(func(w io.Writer, exporter ConfigExporter, mode mode.Mode) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(exporter.Describe()); err != nil {
+			return errors.Wrap(err, "encode metadata")
+		}
+		return nil
+	}))
+}
+
+// ReadSnapshot reads the entries written by the metadata format, or by the
+// metadata view of the config handler, in either JSON or YAML.
+func ReadSnapshot(r io.Reader) ([]ConfigEntry, error) {
+	var entries []ConfigEntry
+	if err := yaml.NewDecoder(r).Decode(&entries); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "decode metadata snapshot")
+	}
+	return entries, nil
+}
+
+type ConfigChange struct {
+	Key string `json:"key" yaml:"key"`
+	// Kind is one of the Change constants. Diff only reports ChangeDefault,
+	// ChangeType and ChangeValidate as changes.
+	Kind string `json:"kind" yaml:"kind"`
+	Old  any    `json:"old,omitempty" yaml:"old,omitempty"`
+	New  any    `json:"new,omitempty" yaml:"new,omitempty"`
+	// Reason is set by CheckCompatibility.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+type ConfigDiff struct {
+	Added   []ConfigEntry  `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed []ConfigEntry  `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Changed []ConfigChange `json:"changed,omitempty" yaml:"changed,omitempty"`
+}
+
+// Diff compares the entries of two versions of an application, keyed by
+// ConfigEntry.Key.
+func Diff(old, new []ConfigEntry) *ConfigDiff {
+	var (
+		diff     = &ConfigDiff{}
+		oldByKey = make(map[string]ConfigEntry, len(old))
+		newByKey = make(map[string]ConfigEntry, len(new))
+	)
+	for _, entry := range old {
+		oldByKey[entry.Key] = entry
+	}
+	for _, entry := range new {
+		newByKey[entry.Key] = entry
+		o, ok := oldByKey[entry.Key]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+			continue
+		}
+		// defaults read back from a snapshot are typed by their decoder, so
+		// they are compared in their formatted form
+		if o.HasDefault != entry.HasDefault || !sameValue(o.Default, entry.Default) {
+			diff.Changed = append(diff.Changed, ConfigChange{Key: entry.Key, Kind: ChangeDefault, Old: o.Default, New: entry.Default})
+		}
+		if o.Type != entry.Type {
+			diff.Changed = append(diff.Changed, ConfigChange{Key: entry.Key, Kind: ChangeType, Old: o.Type, New: entry.Type})
+		}
+		if !reflect.DeepEqual(o.Validate, entry.Validate) {
+			diff.Changed = append(diff.Changed, ConfigChange{Key: entry.Key, Kind: ChangeValidate, Old: o.Validate, New: entry.Validate})
+		}
+	}
+	for _, entry := range old {
+		if _, ok := newByKey[entry.Key]; !ok {
+			diff.Removed = append(diff.Removed, entry)
+		}
+	}
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Key < diff.Added[j].Key })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Key < diff.Removed[j].Key })
+	sort.SliceStable(diff.Changed, func(i, j int) bool { return diff.Changed[i].Key < diff.Changed[j].Key })
+	return diff
+}
+
+func (d *ConfigDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String renders the diff as the Markdown "configuration changes" section of
+// release notes.
+func (d *ConfigDiff) String() string {
+	if d.Empty() {
+		return "No configuration changes.\n"
+	}
+	sb := &strings.Builder{}
+	if len(d.Added) != 0 {
+		sb.WriteString("### Added\n\n")
+		for _, entry := range d.Added {
+			fmt.Fprintf(sb, "- `%s` (%s", entry.Key, entry.Type)
+			if entry.HasDefault {
+				fmt.Fprintf(sb, ", default %s", formatChangeValue(entry.Default))
+			}
+			if entry.Required {
+				sb.WriteString(", required")
+			}
+			sb.WriteString(")")
+			if entry.Description != "" {
+				fmt.Fprintf(sb, ": %s", entry.Description)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	if len(d.Removed) != 0 {
+		sb.WriteString("### Removed\n\n")
+		for _, entry := range d.Removed {
+			fmt.Fprintf(sb, "- `%s`\n", entry.Key)
+		}
+		sb.WriteString("\n")
+	}
+	if len(d.Changed) != 0 {
+		sb.WriteString("### Changed\n\n")
+		for _, change := range d.Changed {
+			fmt.Fprintf(sb, "- `%s`: %s changed from %s to %s\n", change.Key, change.Kind,
+				formatChangeValue(change.Old), formatChangeValue(change.New))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func formatChangeValue(v any) string {
+	if v == nil {
+		return "none"
+	}
+	if rules, ok := v.([]string); ok {
+		return "`" + strings.Join(rules, ",") + "`"
+	}
+	s, err := strconv2.FormatAny(v)
+	if err != nil {
+		s = fmt.Sprint(v)
+	}
+	return "`" + s + "`"
+}
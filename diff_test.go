@@ -0,0 +1,77 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ReleaseV1 struct {
+	Host    string `prop:"server.host:localhost"`
+	Port    int    `prop:"server.port:8080,validate=min=1"`
+	Mode    string `prop:"server.mode"`
+	Workers int    `prop:"server.workers:4"`
+}
+
+type ReleaseV2 struct {
+	Host    string  `prop:"server.host:0.0.0.0"`
+	Port    int     `prop:"server.port:8080,validate=min=1 max=65535"`
+	Workers float64 `prop:"server.workers:4"`
+	Debug   bool    `prop:"server.debug:false" desc:"enable debug endpoints"`
+}
+
+func snapshot(t *testing.T, component any) []ConfigEntry {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(app.LogError, app.SetComponents(component, exporter))
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, exporter.Export(buf, FormatMetadata, 0))
+	entries, err := ReadSnapshot(buf)
+	assert.NoError(t, err)
+	return entries
+}
+
+func TestDiff(t *testing.T) {
+	diff := Diff(snapshot(t, &ReleaseV1{}), snapshot(t, &ReleaseV2{}))
+	assert.Equal(t, []string{"server.debug"}, keysOf(diff.Added))
+	assert.Equal(t, []string{"server.mode"}, keysOf(diff.Removed))
+	assert.Equal(t, []ConfigChange{
+		{Key: "server.host", Kind: ChangeDefault, Old: "localhost", New: "0.0.0.0"},
+		{Key: "server.port", Kind: ChangeValidate, Old: []string{"min=1"}, New: []string{"min=1", "max=65535"}},
+		{Key: "server.workers", Kind: ChangeType, Old: "int", New: "float64"},
+	}, diff.Changed)
+	assert.Equal(t, "### Added\n\n"+
+		"- `server.debug` (bool, default `false`): enable debug endpoints\n\n"+
+		"### Removed\n\n"+
+		"- `server.mode`\n\n"+
+		"### Changed\n\n"+
+		"- `server.host`: default changed from `localhost` to `0.0.0.0`\n"+
+		"- `server.port`: validate changed from `min=1` to `min=1,max=65535`\n"+
+		"- `server.workers`: type changed from `int` to `float64`\n\n", diff.String())
+
+	assert.True(t, Diff(snapshot(t, &ReleaseV1{}), snapshot(t, &ReleaseV1{})).Empty())
+
+	t.Run("SnapshotAgainstLive", func(t *testing.T) {
+		exporter := NewConfigExporter()
+		_, err := ioc.Run(app.LogError, app.SetComponents(&DiffDefaults{}, exporter))
+		assert.NoError(t, err)
+		diff := Diff(snapshot(t, &DiffDefaults{}), exporter.Describe())
+		assert.True(t, diff.Empty(), diff.String())
+	})
+}
+
+type DiffDefaults struct {
+	Port   int            `prop:"server.port:8080"`
+	Ratio  float64        `prop:"server.ratio:0.5"`
+	Limits map[string]int `prop:"server.limits:map[conns:100]"`
+}
+
+func keysOf(entries []ConfigEntry) []string {
+	var keys []string
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
@@ -0,0 +1,182 @@
+package config_exporter
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pkg/errors"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DescriptionSource configures GenerateDescriptions.
+type DescriptionSource struct {
+	// Dir is the directory of the package, "." by default.
+	Dir string
+	// Types lists the struct types to describe. By default every struct type
+	// with a Prefix method is described.
+	Types []string
+	// Mapper is the struct tag naming the keys, "yaml" by default.
+	Mapper string
+	// Command is named in the generated header, "config-desc" by default.
+	Command string
+}
+
+// GenerateDescriptions writes a Go file declaring a ConfigDescription method,
+// built from the doc comments of the fields, for the struct types of the
+// package in src.Dir. Types already declaring ConfigDescription, and fields
+// without comments or with a desc tag, are skipped, but listing such a type
+// in src.Types is an error. It backs the config-desc command, meant to run
+// from a go:generate directive next to the configuration structs.
+func GenerateDescriptions(w io.Writer, src DescriptionSource) error {
+	if src.Dir == "" {
+		src.Dir = "."
+	}
+	if src.Mapper == "" {
+		src.Mapper = "yaml"
+	}
+	if src.Command == "" {
+		src.Command = "config-desc"
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, src.Dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return errors.Wrapf(err, "parse package in '%s'", src.Dir)
+	}
+	if len(pkgs) != 1 {
+		return errors.Errorf("expect one package in '%s', found %d", src.Dir, len(pkgs))
+	}
+	var files []*ast.File
+	var name string
+	for pkgName, pkg := range pkgs {
+		name = pkgName
+		for _, file := range pkg.Files {
+			if !generatedBy(file, src.Command) {
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return fset.File(files[i].Pos()).Name() < fset.File(files[j].Pos()).Name()
+	})
+	p, err := doc.NewFromFiles(fset, files, name, doc.PreserveAST|doc.AllDecls)
+	if err != nil {
+		return errors.Wrapf(err, "read documentation of package '%s'", name)
+	}
+
+	var (
+		wanted = make(map[string]bool)
+		buf    = &bytes.Buffer{}
+	)
+	for _, t := range src.Types {
+		wanted[t] = true
+	}
+	fmt.Fprintf(buf, "// Code generated by %s; DO NOT EDIT.\n\npackage %s\n", src.Command, name)
+	for _, t := range p.Types {
+		if len(src.Types) != 0 && !wanted[t.Name] || len(src.Types) == 0 && !hasMethod(t, "Prefix") {
+			continue
+		}
+		delete(wanted, t.Name)
+		if hasMethod(t, "ConfigDescription") {
+			if len(src.Types) != 0 {
+				return errors.Errorf("struct type %s in package '%s' already declares ConfigDescription", t.Name, name)
+			}
+			continue
+		}
+		st, ok := structOf(t)
+		if !ok {
+			continue
+		}
+		descriptions := fieldDescriptions(st, src.Mapper)
+		if len(descriptions) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "\nfunc (c *%s) ConfigDescription() map[string]string {\n\treturn map[string]string{\n", t.Name)
+		for _, d := range descriptions {
+			fmt.Fprintf(buf, "\t\t%s: %s,\n", strconv.Quote(d[0]), strconv.Quote(d[1]))
+		}
+		buf.WriteString("\t}\n}\n")
+	}
+	if len(wanted) != 0 {
+		var missing []string
+		for t := range wanted {
+			missing = append(missing, t)
+		}
+		sort.Strings(missing)
+		return errors.Errorf("struct types %v not found in package '%s'", missing, name)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "format generated source")
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+// generatedBy reports whether file is an earlier output of command, which is
+// left out so that its methods don't hide the types it describes.
+func generatedBy(file *ast.File, command string) bool {
+	return ast.IsGenerated(file) && strings.Contains(file.Comments[0].Text(), "Code generated by "+command+";")
+}
+
+func hasMethod(t *doc.Type, name string) bool {
+	for _, m := range t.Methods {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func structOf(t *doc.Type) (*ast.StructType, bool) {
+	for _, spec := range t.Decl.Specs {
+		if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == t.Name {
+			st, ok := ts.Type.(*ast.StructType)
+			return st, ok
+		}
+	}
+	return nil, false
+}
+
+// fieldDescriptions returns the key and the doc comment, or else the line
+// comment, of the exported named fields of st that have no desc tag.
+func fieldDescriptions(st *ast.StructType, mapper string) [][2]string {
+	var descriptions [][2]string
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+		text := field.Doc.Text()
+		if text == "" {
+			text = field.Comment.Text()
+		}
+		text = strings.Join(strings.Fields(text), " ")
+		if text == "" || tag.Get(DescriptionTag) != "" {
+			continue
+		}
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			key := fieldName(reflect.StructField{Name: ident.Name, Tag: tag}, mapper)
+			if key == "-" {
+				continue
+			}
+			descriptions = append(descriptions, [2]string{key, text})
+		}
+	}
+	return descriptions
+}
@@ -0,0 +1,54 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateDescriptions(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := GenerateDescriptions(buf, DescriptionSource{Dir: "testdata/docgen"})
+	assert.NoError(t, err)
+	assert.Equal(t, `// Code generated by config-desc; DO NOT EDIT.
+
+package docgen
+
+func (c *CacheConfig) ConfigDescription() map[string]string {
+	return map[string]string{
+		"ttl":  "TTL is the time to live of an entry, in seconds.",
+		"size": "max entries kept in memory",
+		"sub":  "Sub is not described by its own type.",
+	}
+}
+`, buf.String())
+
+	t.Run("Types", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := GenerateDescriptions(buf, DescriptionSource{Dir: "testdata/docgen", Types: []string{"SubConfig"}})
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `func (c *SubConfig) ConfigDescription() map[string]string {
+	return map[string]string{
+		"Key": "Key of the sub config.",
+	}
+}`)
+		err = GenerateDescriptions(&bytes.Buffer{}, DescriptionSource{Dir: "testdata/docgen", Types: []string{"Missing"}})
+		assert.EqualError(t, err, "struct types [Missing] not found in package 'docgen'")
+		err = GenerateDescriptions(&bytes.Buffer{}, DescriptionSource{Dir: "testdata/docgen", Types: []string{"Described"}})
+		assert.EqualError(t, err, "struct type Described in package 'docgen' already declares ConfigDescription")
+	})
+
+	t.Run("Regenerate", func(t *testing.T) {
+		dir := t.TempDir()
+		source, err := os.ReadFile("testdata/docgen/config.go")
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"), source, 0o644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "config_description_gen.go"), buf.Bytes(), 0o644))
+		again := &bytes.Buffer{}
+		err = GenerateDescriptions(again, DescriptionSource{Dir: dir})
+		assert.NoError(t, err)
+		assert.Equal(t, buf.String(), again.String())
+	})
+}
@@ -0,0 +1,132 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/go-kid/properties"
+	"github.com/go-kid/strconv2"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type TemplateDrift struct {
+	// Missing lists the bound keys absent from the template.
+	Missing []string `json:"missing,omitempty" yaml:"missing,omitempty"`
+	// Unbound lists the keys of the template no component binds anymore.
+	Unbound []string `json:"unbound,omitempty" yaml:"unbound,omitempty"`
+	// Diverged lists the keys whose template value differs from the tag
+	// default, with Old being the template value and New the default.
+	Diverged []ConfigChange `json:"diverged,omitempty" yaml:"diverged,omitempty"`
+}
+
+// CheckTemplate compares the YAML, JSON or TOML template at path with the
+// keys bound by the components of exporter.
+func CheckTemplate(exporter ConfigExporter, path string) (*TemplateDrift, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read template: %s", path)
+	}
+	var raw map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml", ".json":
+		err = yaml.Unmarshal(bytes, &raw)
+	case ".toml":
+		err = toml.Unmarshal(bytes, &raw)
+	default:
+		return nil, errors.Errorf("unsupported template file extension '%s'", ext)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse template: %s", path)
+	}
+	p, err := properties.NewFromAny(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "flatten template: %s", path)
+	}
+	template := p.ToPropertiesMap()
+
+	var (
+		drift   = &TemplateDrift{}
+		entries = exporter.Describe()
+		known   = make([]string, len(entries))
+		keys    = make([]string, 0, len(template))
+	)
+	for key := range template {
+		keys = append(keys, key)
+	}
+	for i, entry := range entries {
+		known[i] = entry.Key
+		if _, ok := p.Get(entry.Key); !ok && !containsBound(keys, entry.Key) {
+			drift.Missing = append(drift.Missing, entry.Key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !isBound(key, known) {
+			drift.Unbound = append(drift.Unbound, key)
+		}
+	}
+	for _, entry := range entries {
+		if !entry.HasDefault || entry.Sensitive {
+			continue
+		}
+		for _, key := range keys {
+			if !strings.EqualFold(key, entry.Key) || sameValue(template[key], entry.Default) {
+				continue
+			}
+			drift.Diverged = append(drift.Diverged, ConfigChange{
+				Key:  entry.Key,
+				Kind: ChangeDefault,
+				Old:  template[key],
+				New:  entry.Default,
+			})
+		}
+	}
+	return drift, nil
+}
+
+// containsBound reports whether key or any key nested in it is in keys.
+func containsBound(keys []string, key string) bool {
+	for _, k := range keys {
+		if isBound(k, []string{key}) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameValue(a, b any) bool {
+	as, err := strconv2.FormatAny(a)
+	if err != nil {
+		return false
+	}
+	bs, err := strconv2.FormatAny(b)
+	return err == nil && as == bs
+}
+
+func (d *TemplateDrift) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Unbound) == 0 && len(d.Diverged) == 0
+}
+
+// Err returns an error describing the drift, or nil when the template is in
+// sync with the code.
+func (d *TemplateDrift) Err() error {
+	if d.Empty() {
+		return nil
+	}
+	var lines []string
+	for _, key := range d.Missing {
+		lines = append(lines, fmt.Sprintf("%s: missing from template", key))
+	}
+	for _, key := range d.Unbound {
+		lines = append(lines, fmt.Sprintf("%s: not bound by any component", key))
+	}
+	for _, change := range d.Diverged {
+		lines = append(lines, fmt.Sprintf("%s: template value %s differs from default %s",
+			change.Key, formatChangeValue(change.Old), formatChangeValue(change.New)))
+	}
+	return errors.Errorf("configuration template drifted:\n\t%s", strings.Join(lines, "\n\t"))
+}
@@ -0,0 +1,69 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type DriftComponent struct {
+	Host    string            `prop:"server.host:localhost"`
+	Port    int               `prop:"server.port:8080"`
+	Timeout string            `prop:"server.timeout:5s"`
+	Labels  map[string]string `prop:"server.labels"`
+	APIKey  string            `prop:"server.apiKey:abc"`
+}
+
+func TestCheckTemplate(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(app.LogError, app.SetComponents(&DriftComponent{}, exporter))
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("Drifted", func(t *testing.T) {
+		drift, err := CheckTemplate(exporter, write("config.example.yaml", `
+server:
+    host: localhost
+    port: 9090
+    apiKey: changeme
+    labels:
+        team: platform
+    mode: debug
+`))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"server.timeout"}, drift.Missing)
+		assert.Equal(t, []string{"server.mode"}, drift.Unbound)
+		assert.Equal(t, []ConfigChange{
+			{Key: "server.port", Kind: ChangeDefault, Old: 9090, New: float64(8080)},
+		}, drift.Diverged)
+		assert.EqualError(t, drift.Err(), "configuration template drifted:\n"+
+			"\tserver.timeout: missing from template\n"+
+			"\tserver.mode: not bound by any component\n"+
+			"\tserver.port: template value `9090` differs from default `8080`")
+	})
+	t.Run("InSync", func(t *testing.T) {
+		drift, err := CheckTemplate(exporter, write("config.example.toml", `
+[server]
+host = "localhost"
+port = 8080
+timeout = "5s"
+apiKey = ""
+labels = {}
+`))
+		assert.NoError(t, err)
+		assert.True(t, drift.Empty(), drift.Err())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := CheckTemplate(exporter, write("config.ini", ""))
+		assert.EqualError(t, err, "unsupported template file extension '.ini'")
+	})
+}
@@ -0,0 +1,79 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/ioc/syslog"
+	"github.com/go-kid/ioc/util/reflectx"
+	"github.com/go-kid/strconv2"
+	"reflect"
+)
+
+func copyValue(v reflect.Value) reflect.Value {
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	return copied
+}
+
+// captureEffectiveValue records the value the application receives at
+// runtime. In dry-run mode it replays the binding of property onto the value
+// the field held before the exporter replaced it with a placeholder. Otherwise
+// the field holds it, and the binding is replayed onto a placeholder instead
+// to build the template of a prefix.
+func (d *postProcessor) captureEffectiveValue(property *component_definition.Property) {
+	if !d.dryRun {
+		d.effectiveValues[property.ID()] = copyValue(property.Value).Interface()
+		if property.Tag == definition.PrefixTag {
+			template := reflect.New(property.Type).Elem()
+			template.Set(reflect.ValueOf(reflectx.ZeroValue(property.Type)))
+			d.prefixTemplates[property.ID()] = d.rebind(property, template)
+		}
+		return
+	}
+	origin, ok := d.originValues[property.ID()]
+	if !ok {
+		return
+	}
+	d.effectiveValues[property.ID()] = d.rebind(property, copyValue(origin))
+}
+
+// rebind replays the binding of property onto v and returns its value.
+func (d *postProcessor) rebind(property *component_definition.Property, v reflect.Value) any {
+	var (
+		configValue any
+		err         error
+	)
+	if property.Tag == definition.PrefixTag {
+		configValue = property.Configurations[property.TagVal]
+	} else if property.TagVal != "" {
+		configValue, err = strconv2.ParseAny(property.TagVal)
+	}
+	if err == nil {
+		bound := property.Value
+		property.Value = v
+		err = property.Unmarshall(configValue)
+		property.Value = bound
+	}
+	if err != nil {
+		syslog.Warnf("rebind configuration of '%s' err: %v", property, err)
+	}
+	return v.Interface()
+}
+
+// templateValueOf returns the template of the value bound by a prefix.
+func (d *postProcessor) templateValueOf(property *component_definition.Property) any {
+	if template, ok := d.prefixTemplates[property.ID()]; ok {
+		return template
+	}
+	return property.Value.Interface()
+}
+
+// effectiveValueOf returns the effective value of key bound by a value tag.
+// A tag quoting several keys is rendered into one field, so each key then
+// reports its own resolved value.
+func (d *postProcessor) effectiveValueOf(property *component_definition.Property, key string) any {
+	if len(property.Configurations) == 1 {
+		return d.effectiveValues[property.ID()]
+	}
+	return property.Configurations[key]
+}
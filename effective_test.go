@@ -0,0 +1,171 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io"
+	"testing"
+	"time"
+)
+
+type HTTPPool struct {
+	Size int           `yaml:"size"`
+	Idle time.Duration `yaml:"idle"`
+}
+
+type EffectiveComponent struct {
+	Host    string        `prop:"http.host"`
+	Port    int           `prop:"http.port:8080"`
+	Retries int           `prop:"http.retries"`
+	Timeout time.Duration `value:"${http.timeout:5s}"`
+	Pool    HTTPPool      `prefix:"http.pool"`
+	Token   string        `prop:"http.token:secret"`
+}
+
+func TestEffectiveValues(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&EffectiveComponent{
+			Retries: 3,
+			Pool:    HTTPPool{Size: 10, Idle: time.Minute},
+		}, exporter),
+		app.AddConfigLoader(loader.NewRawLoader([]byte(`
+http:
+    host: example.com
+    pool:
+        size: 20
+`))),
+	)
+	assert.NoError(t, err)
+
+	t.Run("Template", func(t *testing.T) {
+		bytes, err := yaml.Marshal(exporter.GetConfig(0))
+		assert.NoError(t, err)
+		assert.Equal(t, `http:
+    host: example.com
+    pool:
+        idle: 0s
+        size: 20
+    port: 8080
+    retries: 0
+    timeout: 5s
+    token: '******'
+`, string(bytes))
+	})
+	t.Run("Effective", func(t *testing.T) {
+		// a configured prefix is decoded into a fresh struct, dropping the preset idle
+		bytes, err := yaml.Marshal(exporter.GetConfig(EffectiveValues | RevealSensitive))
+		assert.NoError(t, err)
+		assert.Equal(t, `http:
+    host: example.com
+    pool:
+        idle: 0s
+        size: 20
+    port: 8080
+    retries: 3
+    timeout: 5s
+    token: secret
+`, string(bytes))
+	})
+	t.Run("Describe", func(t *testing.T) {
+		entries := exporter.Describe()
+		for key, want := range map[string]any{
+			"http.port":      8080,
+			"http.retries":   3,
+			"http.pool.size": 20,
+			"http.token":     MaskedValue,
+		} {
+			entry := findEntry(entries, key)
+			assert.NotNil(t, entry, key)
+			assert.Equal(t, want, entry.Effective, key)
+		}
+		assert.EqualValues(t, 8080, findEntry(entries, "http.port").Default)
+	})
+}
+
+type RuntimeComponent struct {
+	Host        string   `prop:"rt.host"`
+	Port        int      `prop:"rt.port:8080"`
+	Pool        HTTPPool `prefix:"rt.pool"`
+	initialized bool
+	ran         bool
+}
+
+func (c *RuntimeComponent) Init() error {
+	c.initialized = true
+	return nil
+}
+
+func (c *RuntimeComponent) Run() error {
+	c.ran = true
+	return nil
+}
+
+func TestRuntimeConfigExporter(t *testing.T) {
+	var (
+		component = &RuntimeComponent{}
+		exporter  = NewRuntimeConfigExporter()
+	)
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(component, exporter),
+		app.AddConfigLoader(loader.NewRawLoader([]byte(`
+rt:
+    host: example.com
+    pool:
+        size: 20
+`))),
+	)
+	assert.NoError(t, err)
+	assert.True(t, component.initialized)
+	assert.True(t, component.ran)
+	assert.Equal(t, "example.com", component.Host)
+	assert.Equal(t, 8080, component.Port)
+	assert.Equal(t, 20, component.Pool.Size)
+
+	for _, m := range []mode.Mode{0, EffectiveValues} {
+		bytes, err := yaml.Marshal(exporter.GetConfig(m))
+		assert.NoError(t, err)
+		assert.Equal(t, `rt:
+    host: example.com
+    pool:
+        idle: 0s
+        size: 20
+    port: 8080
+`, string(bytes))
+	}
+
+	t.Run("Required", func(t *testing.T) {
+		_, err := ioc.Run(
+			app.LogError,
+			app.SetComponents(&RuntimeComponent{}, NewRuntimeConfigExporter()),
+		)
+		assert.Error(t, err)
+	})
+}
+
+type NilPointerComponent struct {
+	Ptr  *int `prop:"pp.ptr"`
+	Port int  `prop:"pp.port:8080"`
+}
+
+func TestEffectiveNilPointer(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&NilPointerComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+	for _, format := range Formatters() {
+		t.Run(format, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.NoError(t, exporter.Export(io.Discard, format, EffectiveValues))
+			})
+		})
+	}
+}
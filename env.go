@@ -0,0 +1,167 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/strconv2"
+	"github.com/pkg/errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// EnvNamer maps a configuration key to the name of the environment variable
+// setting it.
+type EnvNamer interface {
+	EnvName(key string) string
+}
+
+// EnvNaming upper-cases keys and replaces the characters that can't appear
+// in a variable name with '_', so that "Merge.SubP2.sub" is named
+// "MERGE_SUBP2_SUB".
+type EnvNaming struct {
+	// Prefix is prepended to every name, separated by '_'.
+	Prefix string
+	// Separator replaces the dots between key segments, "_" by default.
+	Separator string
+}
+
+func (n EnvNaming) EnvName(key string) string {
+	separator := n.Separator
+	if separator == "" {
+		separator = "_"
+	}
+	segments := strings.Split(key, ".")
+	for i, seg := range segments {
+		segments[i] = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z':
+				return r - 'a' + 'A'
+			case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+				return r
+			default:
+				return '_'
+			}
+		}, seg)
+	}
+	name := strings.Join(segments, separator)
+	if n.Prefix != "" {
+		name = strings.TrimSuffix(n.Prefix, "_") + "_" + name
+	}
+	return name
+}
+
+// DefaultEnvNamer names the variables of the dotenv format, the AnnotationEnv
+// mode and ConfigEntry.Env for the exporters created without WithEnvNamer.
+var DefaultEnvNamer EnvNamer = EnvNaming{}
+
+// WithEnvNamer sets the EnvNamer naming the variables of the dotenv format,
+// the AnnotationEnv mode and ConfigEntry.Env for the exporter.
+func WithEnvNamer(namer EnvNamer) ExporterOption {
+	return func(d *postProcessor) {
+		d.namer = namer
+	}
+}
+
+func (d *postProcessor) envNamer() EnvNamer {
+	if d.namer != nil {
+		return d.namer
+	}
+	return DefaultEnvNamer
+}
+
+type EnvCollision struct {
+	Name string   `json:"name" yaml:"name"`
+	Keys []string `json:"keys" yaml:"keys"`
+}
+
+func (c EnvCollision) String() string {
+	return fmt.Sprintf("%s is shared by keys: %s", c.Name, strings.Join(c.Keys, ", "))
+}
+
+// EnvCollisions lists the variable names namer gives to more than one of
+// keys. Keys only differing in case are the same configuration key and
+// don't collide.
+func EnvCollisions(keys []string, namer EnvNamer) []EnvCollision {
+	var (
+		names  []string
+		byName = make(map[string][]string)
+	)
+	for _, key := range keys {
+		name := namer.EnvName(key)
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		if !containsFold(byName[name], key) {
+			byName[name] = append(byName[name], key)
+		}
+	}
+	sort.Strings(names)
+	var collisions []EnvCollision
+	for _, name := range names {
+		if len(byName[name]) > 1 {
+			sort.Strings(byName[name])
+			collisions = append(collisions, EnvCollision{Name: name, Keys: byName[name]})
+		}
+	}
+	return collisions
+}
+
+func containsFold(arr []string, s string) bool {
+	for _, a := range arr {
+		if strings.EqualFold(a, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDotenvFormatter renders the configuration as a dotenv file with the
+// variables named by namer. Colliding names are documented by a comment
+// preceding their first assignment. The registered dotenv format names them
+// with the EnvNamer of the exporter instead, which also names the variables
+// of the AnnotationEnv mode and of Describe.
+func NewDotenvFormatter(namer EnvNamer) Formatter {
+	return ConfigFormatter(func(w io.Writer, config ExportedConfig) error {
+		return writeDotenv(w, config, namer)
+	})
+}
+
+func formatDotenv(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+	namer := DefaultEnvNamer
+	if d, ok := exporter.(*postProcessor); ok {
+		namer = d.envNamer()
+	}
+	return writeDotenv(w, exportedConfig(exporter, m), namer)
+}
+
+func writeDotenv(w io.Writer, config ExportedConfig, namer EnvNamer) error {
+	var (
+		sets       = config.ValueSets()
+		keys       = make([]string, len(sets))
+		collisions = make(map[string]EnvCollision)
+	)
+	for i, set := range sets {
+		keys[i] = set.Key
+	}
+	for _, collision := range EnvCollisions(keys, namer) {
+		collisions[collision.Name] = collision
+	}
+	for _, set := range sets {
+		val, err := strconv2.FormatAny(set.Value)
+		if err != nil {
+			return errors.Wrapf(err, "format value of '%s'", set.Key)
+		}
+		name := namer.EnvName(set.Key)
+		if collision, ok := collisions[name]; ok {
+			if _, err = fmt.Fprintf(w, "# %s\n", collision); err != nil {
+				return err
+			}
+			delete(collisions, name)
+		}
+		if _, err = fmt.Fprintf(w, "%s=%s\n", name, quoteEnvValue(val)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
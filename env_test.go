@@ -0,0 +1,101 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type EnvComponent struct {
+	SubP2    string `prop:"Merge.SubP2.sub:a"`
+	Dotted   string `prop:"cache.max.size:10"`
+	Underbar string `prop:"cache.max_size:20"`
+}
+
+func TestEnvNaming(t *testing.T) {
+	for _, c := range []struct {
+		naming EnvNaming
+		key    string
+		want   string
+	}{
+		{EnvNaming{}, "Merge.SubP2.sub", "MERGE_SUBP2_SUB"},
+		{EnvNaming{}, "db.max-idle", "DB_MAX_IDLE"},
+		{EnvNaming{Prefix: "APP"}, "server.host", "APP_SERVER_HOST"},
+		{EnvNaming{Prefix: "APP_", Separator: "__"}, "cache.max_size", "APP_CACHE__MAX_SIZE"},
+	} {
+		assert.Equal(t, c.want, c.naming.EnvName(c.key), c.key)
+	}
+}
+
+func TestEnvExport(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&EnvComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	t.Run("Dotenv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, exporter.Export(buf, FormatDotenv, 0))
+		assert.Equal(t, `MERGE_SUBP2_SUB=a
+# CACHE_MAX_SIZE is shared by keys: cache.max.size, cache.max_size
+CACHE_MAX_SIZE=10
+CACHE_MAX_SIZE=20
+`, buf.String())
+	})
+	t.Run("Separator", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		formatter := NewDotenvFormatter(EnvNaming{Separator: "__"})
+		assert.NoError(t, formatter.Format(buf, exporter, 0))
+		assert.Equal(t, `MERGE__SUBP2__SUB=a
+CACHE__MAX__SIZE=10
+CACHE__MAX_SIZE=20
+`, buf.String())
+	})
+	t.Run("Annotation", func(t *testing.T) {
+		bytes, err := yaml.Marshal(exporter.GetConfig(AnnotationEnv))
+		assert.NoError(t, err)
+		assert.Equal(t, `Merge:
+    SubP2:
+        sub: a
+        sub@Env: MERGE_SUBP2_SUB
+cache:
+    max:
+        size: 10
+        size@Env: CACHE_MAX_SIZE
+    max_size: 20
+    max_size@Env: CACHE_MAX_SIZE
+`, string(bytes))
+	})
+	t.Run("Describe", func(t *testing.T) {
+		entries := exporter.Describe()
+		assert.Equal(t, "MERGE_SUBP2_SUB", findEntry(entries, "Merge.SubP2.sub").Env)
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			keys[i] = entry.Key
+		}
+		assert.Equal(t, []EnvCollision{
+			{Name: "CACHE_MAX_SIZE", Keys: []string{"cache.max.size", "cache.max_size"}},
+		}, EnvCollisions(keys, DefaultEnvNamer))
+	})
+}
+
+func TestWithEnvNamer(t *testing.T) {
+	exporter := NewConfigExporter(WithEnvNamer(EnvNaming{Prefix: "APP"}))
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&EnvComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, exporter.Export(buf, FormatDotenv, 0))
+	assert.Contains(t, buf.String(), "APP_MERGE_SUBP2_SUB=a\n")
+	value, _ := exporter.GetConfig(AnnotationEnv).Get("Merge.SubP2.sub@Env")
+	assert.Equal(t, "APP_MERGE_SUBP2_SUB", value)
+	assert.Equal(t, "APP_MERGE_SUBP2_SUB", findEntry(exporter.Describe(), "Merge.SubP2.sub").Env)
+}
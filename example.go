@@ -0,0 +1,121 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/properties"
+	"github.com/go-kid/strconv2"
+	"reflect"
+	"strings"
+)
+
+const ExampleTag = "example"
+
+// ConfigExample can be implemented by configuration structs to return a
+// populated instance, whose non-zero values are exported in place of zero
+// values.
+type ConfigExample interface {
+	ConfigExample() any
+}
+
+var configExampleType = reflect.TypeOf((*ConfigExample)(nil)).Elem()
+
+// exampleOf returns the example of key given by the ConfigExample method of
+// the types on its path or by the `example` tag of its field.
+func exampleOf(property *component_definition.Property, key string) (any, bool) {
+	return nestedValueOf(property, key, property.StructField.Tag.Get(ExampleTag), ExampleTag, configExampleType, func(v reflect.Value) any {
+		return v.Interface().(ConfigExample).ConfigExample()
+	})
+}
+
+// nestedValueOf walks the path of key from the type of property. The first
+// type on the path implementing iface whose instance returned by method holds
+// a non-zero value at the rest of the path gives the value, otherwise the tag
+// named tagName, if any, of the field of key, or tag for the key bound by
+// property itself, is parsed.
+func nestedValueOf(property *component_definition.Property, key, tag, tagName string, iface reflect.Type, method func(v reflect.Value) any) (any, bool) {
+	var (
+		binding = bindingOf(property, key)
+		mapper  = mapperOf(property)
+		rel     []string
+		t       = property.Type
+	)
+	if binding != key {
+		rel = strings.Split(strings.TrimPrefix(key, binding+"."), ".")
+	}
+	for i := 0; ; i++ {
+		if v, ok := newImplementation(t, iface); ok {
+			if value, ok := instanceValue(method(v), rel[i:], mapper); ok {
+				return value, true
+			}
+		}
+		if i == len(rel) {
+			break
+		}
+		t = indirectType(t)
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := findField(t, rel[i], mapper)
+			if !ok {
+				return nil, false
+			}
+			tag, t = "", field.Type
+			if tagName != "" {
+				tag = field.Tag.Get(tagName)
+			}
+		case reflect.Map:
+			tag, t = "", t.Elem()
+		default:
+			return nil, false
+		}
+	}
+	if tag == "" {
+		return nil, false
+	}
+	value, err := strconv2.ParseAny(tag)
+	return value, err == nil
+}
+
+func instanceValue(instance any, rel []string, mapper string) (any, bool) {
+	if instance == nil {
+		return nil, false
+	}
+	if len(rel) == 0 {
+		return instance, true
+	}
+	var p properties.Properties
+	switch e := instance.(type) {
+	case map[string]any:
+		p = properties.Properties(e)
+	default:
+		if kind := indirectType(reflect.TypeOf(instance)).Kind(); kind != reflect.Struct {
+			return nil, false
+		}
+		raw, err := toMap(instance, mapper)
+		if err != nil {
+			return nil, false
+		}
+		collapseTextFields(raw, reflect.ValueOf(instance), mapper)
+		p = raw
+	}
+	value, ok := p.Get(strings.Join(rel, "."))
+	if !ok || value == nil || reflect.ValueOf(value).IsZero() {
+		return nil, false
+	}
+	return value, true
+}
+
+// newImplementation returns a value of t, or a pointer to one, implementing
+// the interface type iface.
+func newImplementation(t reflect.Type, iface reflect.Type) (reflect.Value, bool) {
+	switch {
+	case t.Implements(iface):
+		if t.Kind() == reflect.Pointer {
+			return reflect.New(t.Elem()), true
+		}
+		return reflect.New(t).Elem(), true
+	case t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(iface):
+		return reflect.New(t), true
+	default:
+		return reflect.Value{}, false
+	}
+}
@@ -0,0 +1,85 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type LocaleConfig struct {
+	Default  string         `yaml:"default" example:"en"`
+	Weights  map[string]int `yaml:"weights"`
+	Fallback []string       `yaml:"fallback"`
+	Cache    SubConfig      `yaml:"cache"`
+}
+
+func (c *LocaleConfig) Prefix() string {
+	return "locale"
+}
+
+func (c *LocaleConfig) ConfigExample() any {
+	return &LocaleConfig{
+		Weights:  map[string]int{"en": 10, "de": 5},
+		Fallback: []string{"en", "fr"},
+	}
+}
+
+type ExampleComponent struct {
+	Locale  *LocaleConfig
+	Regions map[string]string `prop:"locale.regions" example:"map[eu:frankfurt us:virginia]"`
+	Secret  string            `prop:"locale.token" example:"abc123"`
+	Port    int               `prop:"locale.port:8080" example:"9090"`
+}
+
+func TestExamples(t *testing.T) {
+	exporter, err := Export(app.SetComponents(&ExampleComponent{}))
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = exporter.Export(buf, FormatYAML, TypedPlaceholders)
+	assert.NoError(t, err)
+	assert.Equal(t, `locale:
+    cache:
+        sub: <string>
+    default: en
+    fallback:
+        - en
+        - fr
+    port: 8080
+    regions:
+        eu: frankfurt
+        us: virginia
+    token: '******'
+    weights:
+        de: 5
+        en: 10
+`, buf.String(), buf.String())
+
+	t.Run("Describe", func(t *testing.T) {
+		entries := exporter.Describe()
+		entry := findEntry(entries, "locale.default")
+		assert.NotNil(t, entry)
+		assert.Equal(t, "en", entry.Example)
+		assert.Equal(t, OriginExample, entry.Origin)
+		assert.Equal(t, MaskedValue, findEntry(entries, "locale.token").Example)
+		assert.Equal(t, OriginDefault, findEntry(entries, "locale.port").Origin)
+	})
+
+	t.Run("Loaded", func(t *testing.T) {
+		exporter, err := Export(
+			app.SetComponents(&ExampleComponent{}),
+			app.SetConfigLoader(loader.NewRawLoader([]byte(`
+locale:
+    default: ja
+`))),
+		)
+		assert.NoError(t, err)
+		config := exporter.GetConfig(0)
+		value, _ := config.Get("locale.default")
+		assert.Equal(t, "ja", value)
+		value, _ = config.Get("locale.weights")
+		assert.Equal(t, map[string]any{"en": 10, "de": 5}, value)
+	})
+}
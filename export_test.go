@@ -0,0 +1,71 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type LifecycleComponent struct {
+	DSN      string   `prop:"db.dsn"`
+	Replicas []string `prop:"db.replicas,validate=len=2"`
+	Greeting Greeting `wire:""`
+}
+
+func (c *LifecycleComponent) AfterPropertiesSet() error {
+	panic("AfterPropertiesSet must not be invoked in dry run")
+}
+
+func (c *LifecycleComponent) Init() error {
+	panic("Init must not be invoked in dry run")
+}
+
+func (c *LifecycleComponent) Run() error {
+	c.Greeting.Hi()
+	return nil
+}
+
+func TestDryRunExport(t *testing.T) {
+	exporter, err := Export(
+		app.LogError,
+		app.SetComponents(&LifecycleComponent{}),
+		app.AddConfigLoader(loader.NewRawLoader([]byte(`
+db:
+    replicas:
+        - a
+        - b
+        - c
+`))),
+	)
+	assert.NoError(t, err)
+	bytes, err := yaml.Marshal(exporter.GetConfig(AnnotationArgs))
+	assert.NoError(t, err)
+	assert.Equal(t, `db:
+    dsn: string
+    dsn@Args:
+        Required: true
+    replicas:
+        - a
+        - b
+        - c
+    replicas@Args:
+        Required: true
+        Validate:
+            - len=2
+`, string(bytes), string(bytes))
+
+	t.Run("NewConfigExporter", func(t *testing.T) {
+		exporter := NewConfigExporter()
+		_, err := ioc.Run(
+			app.LogError,
+			app.SetComponents(&LifecycleComponent{}, exporter),
+		)
+		assert.NoError(t, err)
+		value, ok := exporter.GetConfig(0).Get("db.dsn")
+		assert.True(t, ok)
+		assert.Equal(t, "string", value)
+	})
+}
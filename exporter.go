@@ -2,6 +2,7 @@ package config_exporter
 
 import (
 	"fmt"
+	"github.com/go-kid/ioc"
 	"github.com/go-kid/ioc/app"
 	"github.com/go-kid/ioc/component_definition"
 	"github.com/go-kid/ioc/configure"
@@ -14,6 +15,7 @@ import (
 	"github.com/go-kid/properties"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
+	"io"
 	"reflect"
 )
 
@@ -23,17 +25,67 @@ type postProcessor struct {
 	configure          configure.Configure
 	properties         []*component_definition.Property
 	propertyOriginArgs map[string]component_definition.TagArg
+	originValues       map[string]reflect.Value
+	effectiveValues    map[string]any
+	prefixTemplates    map[string]any
+	declarationOrder   map[string]int
+	dryRun             bool
+	namer              EnvNamer
 }
 
+// ExporterOption configures the exporters returned by NewConfigExporter and
+// NewRuntimeConfigExporter.
+type ExporterOption func(d *postProcessor)
+
 func (d *postProcessor) PostProcessComponentFactory(factory container.Factory) error {
 	d.configure = factory.GetConfigure()
 	return nil
 }
 
-func NewConfigExporter() ConfigExporter {
-	return &postProcessor{
+// NewConfigExporter returns an exporter to register in an application that
+// is run in dry-run mode, only to export its configuration template: each
+// configuration property is bound onto a zero-value placeholder, required
+// keys and validation are relaxed, and no Init, AfterPropertiesSet or Run
+// hook of the components is invoked.
+func NewConfigExporter(opts ...ExporterOption) ConfigExporter {
+	return newPostProcessor(true, opts...)
+}
+
+func newPostProcessor(dryRun bool, opts ...ExporterOption) *postProcessor {
+	d := &postProcessor{
 		propertyOriginArgs: make(map[string]component_definition.TagArg),
+		originValues:       make(map[string]reflect.Value),
+		effectiveValues:    make(map[string]any),
+		prefixTemplates:    make(map[string]any),
+		declarationOrder:   make(map[string]int),
+		dryRun:             dryRun,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// NewRuntimeConfigExporter returns an exporter to register in a running
+// application. It leaves the components alone: their fields receive the
+// bound values, required keys and validation are enforced and every
+// lifecycle hook runs. EffectiveValues then reports what the application
+// bound, while the template is rebuilt from the loaded configuration.
+func NewRuntimeConfigExporter(opts ...ExporterOption) ConfigExporter {
+	return newPostProcessor(false, opts...)
+}
+
+// Export runs the application in dry-run mode with the exporter returned by
+// NewConfigExporter and returns it. Beyond registering the exporter, it
+// records the origins of the loaded keys as by RecordOrigins.
+func Export(opts ...app.SettingOption) (ConfigExporter, error) {
+	exporter := newPostProcessor(true)
+	opts = append([]app.SettingOption{RecordOrigins()}, opts...)
+	_, err := ioc.Run(append(opts, app.SetComponents(exporter))...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "dry run application")
 	}
+	return exporter, nil
 }
 
 func (d *postProcessor) Order() int {
@@ -49,37 +101,84 @@ func copyArg(arg component_definition.TagArg) component_definition.TagArg {
 }
 
 func (d *postProcessor) PostProcessBeforeInstantiation(m *component_definition.Meta, componentName string) (any, error) {
-	if _, ok := m.Raw.(*app.App); ok {
+	if _, ok := m.Raw.(*app.App); ok && d.dryRun {
+		// the App is returned unpopulated, so that it finds no runner to run
 		return m.Raw, nil
 	}
 	for _, prop := range m.GetAllProperties() {
 		if prop.PropertyType == component_definition.PropertyTypeConfiguration {
-			d.propertyOriginArgs[prop.ID()] = copyArg(prop.Args())
 			d.properties = append(d.properties, prop)
+		}
+		if !d.dryRun {
+			continue
+		}
+		if prop.PropertyType == component_definition.PropertyTypeConfiguration {
+			d.propertyOriginArgs[prop.ID()] = copyArg(prop.Args())
+			d.originValues[prop.ID()] = copyValue(prop.Value)
 			prop.Value.Set(reflect.ValueOf(reflectx.ZeroValue(prop.Type)))
+			delete(prop.Args(), processors.ArgValidate)
 		}
 		prop.SetArg(component_definition.ArgRequired, "false")
 	}
+	d.recordDeclarationOrder(m)
 	return nil, nil
 }
 
-func (d *postProcessor) PostProcessBeforeInitialization(component any, componentName string) (any, error) {
-	return nil, nil
+// restoreArgs gives property back the args it was declared with, once the
+// required and validate args relaxed for the dry run have been consumed by
+// the population of its component. Restoring them there rather than on every
+// walk keeps the walks read-only, so that they may run concurrently.
+func (d *postProcessor) restoreArgs(property *component_definition.Property) {
+	origin, ok := d.propertyOriginArgs[property.ID()]
+	if !ok {
+		return
+	}
+	args := property.Args()
+	for argType := range args {
+		delete(args, argType)
+	}
+	for argType, values := range origin {
+		args[argType] = values
+	}
 }
 
-func (d *postProcessor) ForEachConfiguration(f Iterator) {
+// PostProcessBeforeInitialization restores the args and captures the effective
+// values of the bound configurations. In dry-run mode it returns nil, which ends the
+// initialization of the component before its lifecycle hooks.
+func (d *postProcessor) PostProcessBeforeInitialization(component any, componentName string) (any, error) {
 	for _, property := range d.properties {
-		tagArg := d.propertyOriginArgs[property.ID()]
-		for argType, strings := range tagArg {
-			property.SetArg(argType, strings...)
+		if property.Holder.Meta.Name() == componentName {
+			d.restoreArgs(property)
+			d.captureEffectiveValue(property)
 		}
+	}
+	if d.dryRun {
+		return nil, nil
+	}
+	return component, nil
+}
+
+func (d *postProcessor) ForEachConfiguration(f Iterator) {
+	d.forEachConfiguration(false, f)
+}
 
+func (d *postProcessor) forEachConfiguration(effective bool, f Iterator) {
+	if !effective {
+		f = d.templateValues(f)
+	}
+	for _, property := range d.properties {
 		if property.Tag == definition.PrefixTag {
-			invokeHandler(property, property.TagVal, property.Value.Interface(), f)
+			if effective {
+				invokeHandler(property, property.TagVal, d.effectiveValues[property.ID()], f)
+			} else {
+				invokeHandler(property, property.TagVal, d.templateValueOf(property), f)
+			}
 			continue
 		}
 		for p, a := range property.Configurations {
-			if a == nil {
+			if effective {
+				a = d.effectiveValueOf(property, p)
+			} else if a == nil {
 				a = reflectx.ZeroValue(property.Type)
 			}
 			invokeHandler(property, p, a, f)
@@ -87,14 +186,40 @@ func (d *postProcessor) ForEachConfiguration(f Iterator) {
 	}
 }
 
+// templateValues passes the struct defaults and the examples of the keys that
+// aren't loaded to f instead of their zero values.
+func (d *postProcessor) templateValues(f Iterator) Iterator {
+	return func(property *component_definition.Property, key string, value any) {
+		switch d.originOf(property, key, 0) {
+		case OriginDefault:
+			if _, ok := tagDefaultOf(property, bindingOf(property, key), key); !ok {
+				value, _ = structDefaultOf(property, key)
+			}
+		case OriginExample:
+			value, _ = exampleOf(property, key)
+		}
+		f(property, key, value)
+	}
+}
+
 func invokeHandler(property *component_definition.Property, p string, a any, f Iterator) {
 	var mapper = "yaml"
 	if mappers, ok := property.Args().Find("mapper"); ok && len(mappers) != 0 {
 		mapper = mappers[0]
 	}
+	if v := reflect.ValueOf(a); a != nil && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		// a typed nil, such as the effective value of an unset pointer field,
+		// is passed on as nil for the formatters to treat it as missing
+		a = nil
+	}
 	t := reflect.TypeOf(a)
 	if a == nil {
-		a = reflectx.ZeroValue(t)
+		f(property, p, a)
+		return
+	}
+	if isTextType(t) {
+		f(property, p, textValue(reflect.ValueOf(a)))
+		return
 	}
 	switch t.Kind() {
 	case reflect.Struct:
@@ -116,7 +241,7 @@ func invokeHandler(property *component_definition.Property, p string, a any, f I
 
 func (d *postProcessor) GetConfig(mode mode.Mode) properties.Properties {
 	pm := properties.New()
-	d.ForEachConfiguration(func(property *component_definition.Property, prefix string, value any) {
+	d.forEachConfiguration(mode.Eq(EffectiveValues), func(property *component_definition.Property, prefix string, value any) {
 		if mode.Eq(AnnotationArgs) {
 			property.Args().ForEach(func(argType component_definition.ArgType, args []string) {
 				var p = prefix
@@ -142,10 +267,34 @@ func (d *postProcessor) GetConfig(mode mode.Mode) properties.Properties {
 			pm.Add(annoPath, source)
 		}
 
+		if mode.Eq(AnnotationEnv) {
+			pm.Set(fmt.Sprintf("%s@Env", prefix), d.envNamer().EnvName(prefix))
+		}
+
+		if mode.Eq(AnnotationOrigin) {
+			pm.Set(fmt.Sprintf("%s@Origin", prefix), d.originOf(property, prefix, mode))
+		}
+
+		if mode.Eq(AnnotationDescription) {
+			if desc := descriptionOf(property, prefix); desc != "" {
+				pm.Set(fmt.Sprintf("%s@Description", prefix), desc)
+			}
+			if desc := property.StructField.Tag.Get(DescriptionTag); desc != "" && property.Tag == definition.PrefixTag {
+				pm.Set(fmt.Sprintf("%s@Description", property.TagVal), desc)
+			}
+		}
+
 		if origin := d.configure.Get(prefix); origin != nil {
 			if mode.Eq(OnlyNew) {
 				return
 			}
+		} else if mode.Eq(TypedPlaceholders|ExamplePlaceholders) && d.originOf(property, prefix, mode) == OriginPlaceholder {
+			if placeholder, ok := placeholderOf(property, prefix, mode); ok {
+				value = placeholder
+			}
+		}
+		if !mode.Eq(RevealSensitive) && isSensitive(property, prefix) {
+			value = MaskedValue
 		}
 		pm.Set(prefix, value)
 	})
@@ -158,6 +307,7 @@ func convertToProperties(mapper string, value any) []*properties.ValueSet {
 		syslog.Warnf("deep set properties err: %v", err)
 		return nil
 	}
+	collapseTextFields(subRaw, reflect.ValueOf(value), mapper)
 	pm, _ := properties.NewFromAny(subRaw)
 	sets := pm.ValueSets()
 	return sets
@@ -209,3 +359,11 @@ func newDecodeConfig(v any, hooks []mapstructure.DecodeHookFunc) *mapstructure.D
 		MatchName:            nil,
 	}
 }
+
+func (d *postProcessor) Export(w io.Writer, format string, mode mode.Mode) error {
+	formatter, ok := GetFormatter(format)
+	if !ok {
+		return errors.Errorf("unsupported export format '%s', available formats: %v", format, Formatters())
+	}
+	return formatter.Format(w, d, mode)
+}
@@ -0,0 +1,68 @@
+// Package exportertest compares exported configurations with golden files.
+//
+// Run the tests with -exportertest.update to regenerate the golden files:
+//
+//	go test ./... -exportertest.update
+//
+// The flag is named after the package so that it doesn't clash with the
+// -update flag many test packages define for their own golden files.
+package exportertest
+
+import (
+	"bytes"
+	"flag"
+	config_exporter "github.com/go-kid/config-exporter"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/pmezard/go-difflib/difflib"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("exportertest.update", false, "update the golden files of exportertest")
+
+// AssertGolden asserts that the YAML exported by exporter in mode m equals
+// the content of the golden file, reporting a unified diff otherwise.
+func AssertGolden(t testing.TB, exporter config_exporter.ConfigExporter, m mode.Mode, golden string) bool {
+	t.Helper()
+	return AssertGoldenFormat(t, exporter, config_exporter.FormatYAML, m, golden)
+}
+
+// AssertGoldenFormat is AssertGolden for any registered export format.
+func AssertGoldenFormat(t testing.TB, exporter config_exporter.ConfigExporter, format string, m mode.Mode, golden string) bool {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	if err := exporter.Export(buf, format, m); err != nil {
+		t.Errorf("export %s: %v", format, err)
+		return false
+	}
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Errorf("create golden file directory: %v", err)
+			return false
+		}
+		if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+			t.Errorf("update golden file: %v", err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Errorf("read golden file: %v (run with -exportertest.update to create it)", err)
+		return false
+	}
+	if bytes.Equal(want, buf.Bytes()) {
+		return true
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(want)),
+		B:        difflib.SplitLines(buf.String()),
+		FromFile: golden,
+		ToFile:   "exported",
+		Context:  3,
+	})
+	t.Errorf("exported configuration differs from golden file (run with -exportertest.update to accept it):\n%s", diff)
+	return false
+}
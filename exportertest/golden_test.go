@@ -0,0 +1,78 @@
+package exportertest
+
+import (
+	"flag"
+	"fmt"
+	config_exporter "github.com/go-kid/config-exporter"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// a test package importing exportertest may define its own -update flag
+var _ = flag.Bool("update", false, "update the golden files of the test")
+
+type ServerConfig struct {
+	Host string   `prop:"server.host:localhost"`
+	Port int      `prop:"server.port:8080"`
+	Tags []string `prop:"server.tags"`
+}
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertGolden(t *testing.T) {
+	exporter := config_exporter.NewConfigExporter()
+	_, err := ioc.Run(app.LogError, app.SetComponents(&ServerConfig{}, exporter))
+	assert.NoError(t, err)
+
+	t.Run("Equal", func(t *testing.T) {
+		AssertGolden(t, exporter, 0, "testdata/config.golden.yaml")
+		AssertGoldenFormat(t, exporter, config_exporter.FormatDotenv, 0, "testdata/config.golden.env")
+	})
+	t.Run("Differs", func(t *testing.T) {
+		noUpdate(t)
+		golden, err := os.ReadFile("testdata/config.golden.yaml")
+		assert.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "config.golden.yaml")
+		assert.NoError(t, os.WriteFile(path, golden, 0644))
+		r := &recorder{TB: t}
+		assert.False(t, AssertGolden(r, exporter, config_exporter.AnnotationSource, path))
+		if assert.Len(t, r.errors, 1) {
+			assert.Contains(t, r.errors[0], `--- `+path+`
++++ exported
+@@ -1,6 +1,9 @@
+ server:
+     host: localhost
++    host@Sources: github.com/go-kid/config-exporter/exportertest/ServerConfig
+     port: 8080`)
+		}
+	})
+	t.Run("Missing", func(t *testing.T) {
+		noUpdate(t)
+		r := &recorder{TB: t}
+		assert.False(t, AssertGolden(r, exporter, 0, filepath.Join(t.TempDir(), "missing.yaml")))
+		if assert.Len(t, r.errors, 1) {
+			assert.Contains(t, r.errors[0], "run with -exportertest.update to create it")
+		}
+	})
+}
+
+// noUpdate turns -exportertest.update off for the rest of the test, whose golden files
+// are expected to mismatch.
+func noUpdate(t *testing.T) {
+	updating := *update
+	*update = false
+	t.Cleanup(func() { *update = updating })
+}
@@ -0,0 +1,303 @@
+package config_exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-kid/ioc/configure"
+	"github.com/go-kid/properties"
+	"github.com/go-kid/strconv2"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// FlagAnnotation marks the flags defined by NewFlagSet with the key they set.
+	FlagAnnotation = "config-exporter/key"
+	// FlagJSONAnnotation marks the string flags whose value is parsed as JSON.
+	FlagJSONAnnotation = "config-exporter/json"
+)
+
+type FlagDefinition struct {
+	// Name of the flag, equal to Key.
+	Name string `json:"name" yaml:"name"`
+	Key  string `json:"key" yaml:"key"`
+	// Type is the pflag type name, e.g. "int64", "duration" or "stringSlice",
+	// or "json" for a string flag giving the key as a JSON value.
+	Type string `json:"type" yaml:"type"`
+	// Default is the tag default in the flag syntax of Type.
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	Usage   string `json:"usage,omitempty" yaml:"usage,omitempty"`
+}
+
+// FlagDefinitions defines a flag for every leaf key of entries. Keys of
+// struct type are skipped, except for text types such as time.Time and
+// url.URL, and sensitive keys get no default.
+func FlagDefinitions(entries []ConfigEntry) []FlagDefinition {
+	var flags []FlagDefinition
+	for _, entry := range entries {
+		if entry.Kind == reflect.Struct.String() && !isTextEntry(entry) {
+			continue
+		}
+		flag := FlagDefinition{
+			Name:  entry.Key,
+			Key:   entry.Key,
+			Type:  flagType(entry),
+			Usage: entry.Description,
+		}
+		if entry.HasDefault && !entry.Sensitive {
+			flag.Default = flagValue(entry.Default)
+		}
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+func flagType(entry ConfigEntry) string {
+	if entry.Type == "time.Duration" {
+		return "duration"
+	}
+	if isTextEntry(entry) {
+		return "string"
+	}
+	switch entry.Kind {
+	case "bool", "string":
+		return entry.Kind
+	case "int", "int8", "int16", "int32", "int64":
+		return "int64"
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return "uint64"
+	case "float32", "float64":
+		return "float64"
+	case "slice", "array":
+		switch elemKind(entry) {
+		case reflect.Bool:
+			return "boolSlice"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return "int64Slice"
+		case reflect.Float32, reflect.Float64:
+			return "float64Slice"
+		default:
+			return "stringSlice"
+		}
+	case "map":
+		if entry.Type == "map[string]string" {
+			return "stringToString"
+		}
+	}
+	// any other type is given as a JSON value
+	return "json"
+}
+
+// isTextEntry reports whether the key of entry is given as a single string,
+// as the values of text types are exported.
+func isTextEntry(entry ConfigEntry) bool {
+	return entry.goType != nil && isTextType(entry.goType)
+}
+
+// elemKind returns the kind of the elements of a slice or array entry, read
+// from its Go type or, for entries decoded from a snapshot, parsed from Type.
+// Durations are reported as strings, which is how they are given.
+func elemKind(entry ConfigEntry) reflect.Kind {
+	if entry.goType != nil {
+		elem := indirectType(indirectType(entry.goType).Elem())
+		if elem == durationType {
+			return reflect.String
+		}
+		return elem.Kind()
+	}
+	elem := strings.TrimLeft(entry.Type, "*")
+	if strings.HasPrefix(elem, "[") {
+		elem = elem[strings.Index(elem, "]")+1:]
+	}
+	switch strings.TrimLeft(elem, "*") {
+	case "bool":
+		return reflect.Bool
+	case "int", "int8", "int16", "int32", "int64":
+		return reflect.Int64
+	case "float32", "float64":
+		return reflect.Float64
+	}
+	return reflect.String
+}
+
+func flagValue(v any) string {
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+		return strings.Join(elems, ",")
+	case reflect.Map:
+		var pairs []string
+		iter := rv.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, fmt.Sprintf("%v=%v", iter.Key().Interface(), iter.Value().Interface()))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		s, err := strconv2.FormatAny(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return s
+	}
+}
+
+// NewFlagSet defines the flags of FlagDefinitions(entries) in a new flag set.
+// Pass the parsed set to NewFlagLoader to load the values of the flags given
+// on the command line.
+func NewFlagSet(name string, entries []ConfigEntry, errorHandling pflag.ErrorHandling) (*pflag.FlagSet, error) {
+	fs := pflag.NewFlagSet(name, errorHandling)
+	if err := AddFlags(fs, FlagDefinitions(entries)); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// AddFlags defines flags in fs.
+func AddFlags(fs *pflag.FlagSet, flags []FlagDefinition) error {
+	for _, def := range flags {
+		switch def.Type {
+		case "bool":
+			fs.Bool(def.Name, false, def.Usage)
+		case "int64":
+			fs.Int64(def.Name, 0, def.Usage)
+		case "uint64":
+			fs.Uint64(def.Name, 0, def.Usage)
+		case "float64":
+			fs.Float64(def.Name, 0, def.Usage)
+		case "duration":
+			fs.Duration(def.Name, 0, def.Usage)
+		case "boolSlice":
+			fs.BoolSlice(def.Name, nil, def.Usage)
+		case "int64Slice":
+			fs.Int64Slice(def.Name, nil, def.Usage)
+		case "float64Slice":
+			fs.Float64Slice(def.Name, nil, def.Usage)
+		case "stringSlice":
+			fs.StringSlice(def.Name, nil, def.Usage)
+		case "stringToString":
+			// a map flag merges into a default that was set, so it is given upfront
+			fs.StringToString(def.Name, parseStringToString(def.Default), def.Usage)
+		default:
+			fs.String(def.Name, "", def.Usage)
+		}
+		flag := fs.Lookup(def.Name)
+		if def.Default != "" && def.Type != "stringToString" {
+			if err := setFlagDefault(flag, def.Default); err != nil {
+				return errors.Wrapf(err, "set default of flag '%s'", def.Name)
+			}
+			flag.DefValue = flag.Value.String()
+		}
+		if err := fs.SetAnnotation(def.Name, FlagAnnotation, []string{def.Key}); err != nil {
+			return err
+		}
+		if def.Type == "json" {
+			if err := fs.SetAnnotation(def.Name, FlagJSONAnnotation, []string{"true"}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setFlagDefault sets the value of flag without marking it as given, which
+// would make slice flags append the command line values to def.
+func setFlagDefault(flag *pflag.Flag, def string) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		return slice.Replace(strings.Split(def, ","))
+	}
+	return flag.Value.Set(def)
+}
+
+func parseStringToString(def string) map[string]string {
+	if def == "" {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, pair := range strings.Split(def, ",") {
+		k, v, _ := strings.Cut(pair, "=")
+		m[k] = v
+	}
+	return m
+}
+
+type flagLoader struct {
+	fs *pflag.FlagSet
+}
+
+// NewFlagLoader returns a config loader supplying the keys of the flags
+// defined by AddFlags that were set on the command line.
+func NewFlagLoader(fs *pflag.FlagSet) configure.Loader {
+	return &flagLoader{fs: fs}
+}
+
+func (l *flagLoader) String() string {
+	return "flags"
+}
+
+func (l *flagLoader) LoadConfig() ([]byte, error) {
+	var (
+		p   = properties.New()
+		err error
+	)
+	l.fs.Visit(func(flag *pflag.Flag) {
+		keys, ok := flag.Annotations[FlagAnnotation]
+		if !ok || len(keys) == 0 || err != nil {
+			return
+		}
+		var value any
+		value, err = flagConfigValue(l.fs, flag)
+		p.Set(keys[0], value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		return nil, nil
+	}
+	bytes, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshal to YAML: %+v", p)
+	}
+	return bytes, nil
+}
+
+func flagConfigValue(fs *pflag.FlagSet, flag *pflag.Flag) (any, error) {
+	value := flag.Value.String()
+	switch flag.Value.Type() {
+	case "string":
+		if _, ok := flag.Annotations[FlagJSONAnnotation]; ok && value != "" {
+			var v any
+			if err := json.Unmarshal([]byte(value), &v); err != nil {
+				return nil, errors.Wrapf(err, "parse JSON of flag '%s'", flag.Name)
+			}
+			return v, nil
+		}
+		return value, nil
+	case "duration":
+		return value, nil
+	case "stringSlice":
+		return fs.GetStringSlice(flag.Name)
+	case "stringToString":
+		return fs.GetStringToString(flag.Name)
+	}
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		var values []any
+		for _, elem := range slice.GetSlice() {
+			v, err := strconv2.ParseAny(elem)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse flag '%s'", flag.Name)
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	return strconv2.ParseAny(value)
+}
@@ -0,0 +1,127 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type FlagComponent struct {
+	Host    string            `prop:"server.host:localhost" desc:"listen host"`
+	Port    int               `prop:"server.port:8080"`
+	Debug   bool              `prop:"server.debug"`
+	Ratio   float64           `prop:"server.ratio:0.5"`
+	Timeout time.Duration     `prop:"server.timeout:5s"`
+	Tags    []string          `prop:"server.tags:[a,b]"`
+	Ports   []int             `prop:"server.ports"`
+	Labels  map[string]string `prop:"server.labels"`
+	Limits  map[string]int    `prop:"server.limits"`
+	Token   string            `prop:"server.token:secret"`
+}
+
+func TestFlags(t *testing.T) {
+	exporter, err := Export(app.LogError, app.SetComponents(&FlagComponent{}))
+	assert.NoError(t, err)
+
+	t.Run("Definitions", func(t *testing.T) {
+		assert.Equal(t, []FlagDefinition{
+			{Name: "server.debug", Key: "server.debug", Type: "bool"},
+			{Name: "server.host", Key: "server.host", Type: "string", Default: "localhost", Usage: "listen host"},
+			{Name: "server.labels", Key: "server.labels", Type: "stringToString"},
+			{Name: "server.limits", Key: "server.limits", Type: "json"},
+			{Name: "server.port", Key: "server.port", Type: "int64", Default: "8080"},
+			{Name: "server.ports", Key: "server.ports", Type: "int64Slice"},
+			{Name: "server.ratio", Key: "server.ratio", Type: "float64", Default: "0.5"},
+			{Name: "server.tags", Key: "server.tags", Type: "stringSlice", Default: "a,b"},
+			{Name: "server.timeout", Key: "server.timeout", Type: "duration", Default: "5s"},
+			{Name: "server.token", Key: "server.token", Type: "string"},
+		}, FlagDefinitions(exporter.Describe()))
+	})
+	t.Run("Load", func(t *testing.T) {
+		fs, err := NewFlagSet("app", exporter.Describe(), pflag.ContinueOnError)
+		assert.NoError(t, err)
+		assert.Equal(t, "8080", fs.Lookup("server.port").DefValue)
+		assert.NoError(t, fs.Parse([]string{
+			"--server.port=9090",
+			"--server.debug",
+			"--server.timeout=1m",
+			"--server.tags=x,y,z",
+			"--server.ports=80,443",
+			"--server.labels=team=platform",
+			`--server.limits={"conns":100}`,
+		}))
+
+		component := &FlagComponent{}
+		_, err = ioc.Run(
+			app.LogError,
+			app.SetComponents(component),
+			app.AddConfigLoader(NewFlagLoader(fs)),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, &FlagComponent{
+			Host:    "localhost",
+			Port:    9090,
+			Debug:   true,
+			Ratio:   0.5,
+			Timeout: time.Minute,
+			Tags:    []string{"x", "y", "z"},
+			Ports:   []int{80, 443},
+			Labels:  map[string]string{"team": "platform"},
+			Limits:  map[string]int{"conns": 100},
+			Token:   "secret",
+		}, component)
+	})
+}
+
+func TestFlagLoader(t *testing.T) {
+	fs, err := NewFlagSet("app", []ConfigEntry{
+		{Key: "server.host", Type: "string", Kind: "string"},
+		{Key: "server.limits", Type: "map[string]int", Kind: "map"},
+	}, pflag.ContinueOnError)
+	assert.NoError(t, err)
+	assert.NoError(t, fs.Parse([]string{"--server.host=[::1]", `--server.limits={"conns":100}`}))
+	bytes, err := NewFlagLoader(fs).LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, `server:
+    host: '[::1]'
+    limits:
+        conns: 100
+`, string(bytes))
+}
+
+func TestFlagType(t *testing.T) {
+	for _, c := range []struct {
+		goType reflect.Type
+		want   string
+	}{
+		{reflect.TypeOf([]int32{}), "int64Slice"},
+		{reflect.TypeOf([3]int{}), "int64Slice"},
+		{reflect.TypeOf([]*float32{}), "float64Slice"},
+		{reflect.TypeOf([2]bool{}), "boolSlice"},
+		{reflect.TypeOf([]any{}), "stringSlice"},
+		{reflect.TypeOf([]uint{}), "stringSlice"},
+		{reflect.TypeOf([]time.Duration{}), "stringSlice"},
+		{reflect.TypeOf(&[]int{}), "int64Slice"},
+	} {
+		kind := indirectType(c.goType).Kind().String()
+		assert.Equal(t, c.want, flagType(ConfigEntry{Type: c.goType.String(), Kind: kind, goType: c.goType}), c.goType.String())
+		// entries decoded from a snapshot have no Go type
+		assert.Equal(t, c.want, flagType(ConfigEntry{Type: c.goType.String(), Kind: kind}), c.goType.String())
+	}
+
+	t.Run("TextTypes", func(t *testing.T) {
+		exporter, err := Export(app.LogError, app.SetComponents(&PlaceholderComponent{}))
+		assert.NoError(t, err)
+		types := make(map[string]string)
+		for _, flag := range FlagDefinitions(exporter.Describe()) {
+			types[flag.Key] = flag.Type
+		}
+		assert.Equal(t, "string", types["endpoint.since"])
+		assert.Equal(t, "string", types["endpoint.url"])
+		assert.Equal(t, "string", types["endpoint.addr"])
+	})
+}
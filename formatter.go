@@ -0,0 +1,177 @@
+package config_exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/properties"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	FormatYAML       = "yaml"
+	FormatJSON       = "json"
+	FormatTOML       = "toml"
+	FormatProperties = "properties"
+	FormatDotenv     = "dotenv"
+)
+
+type Formatter interface {
+	Format(w io.Writer, exporter ConfigExporter, mode mode.Mode) error
+}
+
+type FormatterFunc func(w io.Writer, exporter ConfigExporter, mode mode.Mode) error
+
+func (f FormatterFunc) Format(w io.Writer, exporter ConfigExporter, mode mode.Mode) error {
+	return f(w, exporter, mode)
+}
+
+// PropertiesFormatter adapts a function that only needs the exported
+// properties into a Formatter. The properties are unordered, so PreserveOrder
+// has no effect on the formats it backs, such as TOML and properties.
+type PropertiesFormatter func(w io.Writer, p properties.Properties) error
+
+func (f PropertiesFormatter) Format(w io.Writer, exporter ConfigExporter, mode mode.Mode) error {
+	return f(w, exporter.GetConfig(mode))
+}
+
+// ExportedConfig is either the properties.Properties returned by GetConfig or,
+// when the mode has PreserveOrder, its OrderedConfig.
+type ExportedConfig interface {
+	ValueSets() []*properties.ValueSet
+}
+
+// ConfigFormatter adapts a function formatting the exported configuration in
+// the order chosen by the mode into a Formatter.
+type ConfigFormatter func(w io.Writer, config ExportedConfig) error
+
+func (f ConfigFormatter) Format(w io.Writer, exporter ConfigExporter, mode mode.Mode) error {
+	return f(w, exportedConfig(exporter, mode))
+}
+
+func exportedConfig(exporter ConfigExporter, mode mode.Mode) ExportedConfig {
+	p := exporter.GetConfig(mode)
+	if mode.Eq(PreserveOrder) {
+		return orderedConfig(exporter, p)
+	}
+	return p
+}
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[string]Formatter{
+		FormatYAML:       ConfigFormatter(formatYAML),
+		"yml":            ConfigFormatter(formatYAML),
+		FormatJSON:       ConfigFormatter(formatJSON),
+		FormatTOML:       PropertiesFormatter(formatTOML),
+		FormatProperties: PropertiesFormatter(formatProperties),
+		FormatDotenv:     FormatterFunc(formatDotenv),
+		"env":            FormatterFunc(formatDotenv),
+	}
+)
+
+func RegisterFormatter(name string, formatter Formatter) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	formatters[strings.ToLower(name)] = formatter
+}
+
+func GetFormatter(name string) (Formatter, bool) {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	formatter, ok := formatters[strings.ToLower(name)]
+	return formatter, ok
+}
+
+func Formatters() []string {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatYAML(w io.Writer, p ExportedConfig) error {
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(p); err != nil {
+		return errors.Wrap(err, "encode YAML")
+	}
+	return encoder.Close()
+}
+
+func formatJSON(w io.Writer, p ExportedConfig) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p); err != nil {
+		return errors.Wrap(err, "encode JSON")
+	}
+	return nil
+}
+
+func formatTOML(w io.Writer, p properties.Properties) error {
+	if err := toml.NewEncoder(w).Encode(normalizeValue(map[string]any(p))); err != nil {
+		return errors.Wrap(err, "encode TOML")
+	}
+	return nil
+}
+
+// normalizeValue converts arrays and non-string keyed maps into generic
+// slices and maps, which encoders with a narrower type support accept.
+func normalizeValue(a any) any {
+	if a == nil {
+		return nil
+	}
+	v := reflect.ValueOf(a)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return normalizeValue(v.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		arr := make([]any, v.Len())
+		for i := range arr {
+			arr[i] = normalizeValue(v.Index(i).Interface())
+		}
+		return arr
+	case reflect.Map:
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprintf("%v", iter.Key().Interface())] = normalizeValue(iter.Value().Interface())
+		}
+		return m
+	default:
+		return a
+	}
+}
+
+func formatProperties(w io.Writer, p properties.Properties) error {
+	bytes, err := p.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "marshal properties")
+	}
+	_, err = w.Write(bytes)
+	return err
+}
+
+func quoteEnvValue(val string) string {
+	if val == "" || strings.ContainsAny(val, " \t\r\n#\"'$\\`") {
+		return strconv.Quote(val)
+	}
+	return val
+}
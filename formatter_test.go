@@ -0,0 +1,105 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type ServerConfig struct {
+	Host string   `prop:"server.host:localhost"`
+	Tags []string `prop:"server.tags:[a,b]"`
+	Mode string   `prop:"server.mode"`
+}
+
+func TestExport(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&ServerConfig{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatYAML,
+			want: `server:
+    host: localhost
+    mode: string
+    tags:
+        - a
+        - b
+`,
+		},
+		{
+			format: FormatJSON,
+			want: `{
+  "server": {
+    "host": "localhost",
+    "mode": "string",
+    "tags": [
+      "a",
+      "b"
+    ]
+  }
+}
+`,
+		},
+		{
+			format: FormatTOML,
+			want: `[server]
+host = 'localhost'
+mode = 'string'
+tags = ['a', 'b']
+`,
+		},
+		{
+			format: FormatProperties,
+			want: `server.host=localhost
+server.mode=string
+server.tags[0]=a
+server.tags[1]=b
+`,
+		},
+		{
+			format: FormatDotenv,
+			want: `SERVER_HOST=localhost
+SERVER_MODE=string
+SERVER_TAGS="[\"a\",\"b\"]"
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := exporter.Export(buf, tt.format, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String(), buf.String())
+		})
+	}
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		err := exporter.Export(io.Discard, "xml", 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("RegisterFormatter", func(t *testing.T) {
+		RegisterFormatter("keys", FormatterFunc(func(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+			for _, set := range exporter.GetConfig(m).ValueSets() {
+				_, _ = io.WriteString(w, set.Key+"\n")
+			}
+			return nil
+		}))
+		buf := &bytes.Buffer{}
+		err := exporter.Export(buf, "KEYS", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "server.host\nserver.mode\nserver.tags\n", buf.String())
+	})
+}
@@ -0,0 +1,179 @@
+package config_exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/properties"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const viewMetadata = "metadata"
+
+var (
+	queryModes = map[string]mode.Mode{
+		"onlyNew":          OnlyNew,
+		"sources":          AnnotationSource,
+		"sourceProperties": AnnotationSourceProperty,
+		"args":             AnnotationArgs,
+		"descriptions":     AnnotationDescription,
+		"effective":        EffectiveValues,
+		"origins":          AnnotationOrigin,
+		"env":              AnnotationEnv,
+		"ordered":          PreserveOrder,
+		"typed":            TypedPlaceholders,
+		"examples":         ExamplePlaceholders,
+	}
+	mediaTypes = map[string]string{
+		"application/json":   FormatJSON,
+		"application/yaml":   FormatYAML,
+		"application/x-yaml": FormatYAML,
+		"text/yaml":          FormatYAML,
+		"text/x-yaml":        FormatYAML,
+		"text/html":          FormatHTML,
+		"*/*":                FormatYAML,
+		"application/*":      FormatYAML,
+		"text/*":             FormatYAML,
+	}
+)
+
+type configHandler struct {
+	exporter ConfigExporter
+}
+
+// NewConfigHandler serves the configuration of exporter. The query parameters
+// onlyNew, sources, sourceProperties, args, descriptions, effective, origins,
+// env, ordered, typed and examples switch on the matching modes, prefix
+// limits the output to a subtree and view=metadata serves the Describe
+// entries instead. YAML or JSON is chosen by the Accept header, while
+// browsers asking for text/html get the HTML configuration browser of the
+// whole tree. Sensitive values are always masked. To serve it from a running
+// application, register the exporter returned by NewRuntimeConfigExporter:
+// NewConfigExporter and Export run the application only to build a template.
+func NewConfigHandler(exporter ConfigExporter) http.Handler {
+	return &configHandler{exporter: exporter}
+}
+
+func (h *configHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	format, contentType, ok := negotiate(r.Header.Get("Accept"))
+	if !ok {
+		http.Error(w, "supported media types: application/yaml, application/json, text/html", http.StatusNotAcceptable)
+		return
+	}
+
+	var (
+		query  = r.URL.Query()
+		prefix = query.Get("prefix")
+		body   any
+	)
+	if format == FormatHTML {
+		buf := &bytes.Buffer{}
+		if err := h.exporter.Export(buf, FormatHTML, queryMode(query)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeBody(w, r, contentType, buf)
+		return
+	}
+	if query.Get("view") == viewMetadata {
+		var entries = make([]ConfigEntry, 0)
+		for _, entry := range h.exporter.Describe() {
+			if prefix == "" || entry.Key == prefix || strings.HasPrefix(entry.Key, prefix+".") {
+				entries = append(entries, entry)
+			}
+		}
+		body = entries
+	} else {
+		m := queryMode(query)
+		config := h.exporter.GetConfig(m)
+		if prefix != "" {
+			sub, ok := config.Get(prefix)
+			if !ok {
+				http.Error(w, "configuration prefix '"+prefix+"' not found", http.StatusNotFound)
+				return
+			}
+			config = properties.New()
+			config.Set(prefix, sub)
+		}
+		if m.Eq(PreserveOrder) {
+			body = orderedConfig(h.exporter, config)
+		} else {
+			body = config
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := encodeBody(buf, format, body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeBody(w, r, contentType, buf)
+}
+
+func writeBody(w http.ResponseWriter, r *http.Request, contentType string, buf *bytes.Buffer) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	if r.Method != http.MethodHead {
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+func queryMode(query url.Values) mode.Mode {
+	var m mode.Mode
+	for name, flag := range queryModes {
+		if !query.Has(name) {
+			continue
+		}
+		if v := query.Get(name); v == "" {
+			m |= flag
+		} else if enabled, err := strconv.ParseBool(v); err == nil && enabled {
+			m |= flag
+		}
+	}
+	return m
+}
+
+func negotiate(accept string) (format, contentType string, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		accept = "*/*"
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found && strings.TrimSpace(q) == "0" {
+			continue
+		}
+		if format, ok = mediaTypes[strings.ToLower(strings.TrimSpace(mediaType))]; ok {
+			switch format {
+			case FormatJSON:
+				return format, "application/json; charset=utf-8", true
+			case FormatHTML:
+				return format, "text/html; charset=utf-8", true
+			}
+			return format, "application/yaml; charset=utf-8", true
+		}
+	}
+	return "", "", false
+}
+
+func encodeBody(w io.Writer, format string, body any) error {
+	if format == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(body)
+	}
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(body); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,108 @@
+package config_exporter
+
+import (
+	"encoding/json"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestConfigHandler(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&ServerConfig{}, &SensitiveComponent{}, exporter),
+		app.AddConfigLoader(loader.NewRawLoader([]byte(`
+server:
+    host: example.com
+`))),
+	)
+	assert.NoError(t, err)
+	handler := NewConfigHandler(exporter)
+
+	serve := func(target, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("YAMLByDefault", func(t *testing.T) {
+		rec := serve("/?prefix=server", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/yaml; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `server:
+    host: example.com
+    mode: string
+    tags:
+        - a
+        - b
+`, rec.Body.String())
+	})
+	t.Run("JSONWithModes", func(t *testing.T) {
+		rec := serve("/?prefix=server&onlyNew&sources=false&args=true", "application/json")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"server": {
+			"mode": "string",
+			"mode@Args": {"Required": true},
+			"tags": ["a", "b"],
+			"tags@Args": {"Required": true},
+			"host@Args": {"Required": true}
+		}}`, rec.Body.String())
+	})
+	t.Run("Masked", func(t *testing.T) {
+		rec := serve("/?prefix=db.password", "application/json")
+		assert.JSONEq(t, `{"db": {"password": "******"}}`, rec.Body.String())
+	})
+	t.Run("Metadata", func(t *testing.T) {
+		rec := serve("/?view=metadata&prefix=server", "application/json")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var entries []ConfigEntry
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+		assert.Len(t, entries, 3)
+		assert.Equal(t, "server.host", entries[0].Key)
+		assert.Equal(t, "example.com", entries[0].Value)
+	})
+	t.Run("HTML", func(t *testing.T) {
+		rec := serve("/", "text/html,application/xhtml+xml,*/*;q=0.8")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"key":"db.password"`)
+		assert.NotContains(t, rec.Body.String(), "p@ss")
+	})
+	t.Run("PrefixNotFound", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve("/?prefix=none", "").Code)
+	})
+	t.Run("NotAcceptable", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotAcceptable, serve("/", "image/png").Code)
+	})
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		// run with -race: serving must not write to the shared properties
+		targets := []string{"/?args", "/?sources&descriptions", "/?view=metadata", "/?effective&origins&ordered"}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(target string) {
+				defer wg.Done()
+				rec := serve(target, "application/json")
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}(targets[i%len(targets)])
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,121 @@
+package config_exporter
+
+import (
+	"encoding/json"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FormatHelmValues = "helm-values"
+	FormatHelmSchema = "helm-schema"
+
+	// HelmSchemaDraft is the draft understood by the schema validation of
+	// every Helm 3 release.
+	HelmSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+	defaultHelmRootKey = "config"
+)
+
+type HelmOptions struct {
+	// RootKey is the dotted path of the values the configuration is nested
+	// under, "config" by default.
+	RootKey string
+}
+
+func init() {
+	RegisterFormatter(FormatHelmValues, NewHelmValuesFormatter(HelmOptions{}))
+	RegisterFormatter(FormatHelmSchema, NewHelmSchemaFormatter(HelmOptions{}))
+}
+
+func (opts HelmOptions) path() []string {
+	if opts.RootKey == "" {
+		return []string{defaultHelmRootKey}
+	}
+	return strings.Split(opts.RootKey, ".")
+}
+
+// NewHelmValuesFormatter renders the configuration as a values.yaml section
+// under opts.RootKey. Unless the mode has RevealSensitive, sensitive keys hold
+// SecretPlaceholder.
+func NewHelmValuesFormatter(opts HelmOptions) Formatter {
+	return FormatterFunc(func(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+		p := exporter.GetConfig(m &^ annotationModes)
+		if !m.Eq(RevealSensitive) {
+			sensitiveKeys := sensitiveKeysOf(exporter)
+			for _, set := range p.ValueSets() {
+				if isBound(set.Key, sensitiveKeys) {
+					p.Set(set.Key, SecretPlaceholder)
+				}
+			}
+		}
+		var values any = p
+		if m.Eq(PreserveOrder) {
+			values = orderedConfig(exporter, p)
+		}
+		path := opts.path()
+		for i := len(path) - 1; i >= 0; i-- {
+			values = map[string]any{path[i]: values}
+		}
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(values); err != nil {
+			return errors.Wrap(err, "encode Helm values")
+		}
+		return encoder.Close()
+	})
+}
+
+// NewHelmSchemaFormatter renders the values.schema.json matching the values
+// of NewHelmValuesFormatter.
+func NewHelmSchemaFormatter(opts HelmOptions) Formatter {
+	return FormatterFunc(func(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+		schema := exporter.JSONSchema()
+		schema.Schema = ""
+		path := opts.path()
+		for i := len(path) - 1; i >= 0; i-- {
+			parent := &JSONSchema{Type: "object"}
+			parent.setProperty(path[i], schema)
+			if len(schema.Required) != 0 {
+				parent.Required = []string{path[i]}
+			}
+			schema = parent
+		}
+		schema.Schema = HelmSchemaDraft
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(schema); err != nil {
+			return errors.Wrap(err, "encode Helm values schema")
+		}
+		return nil
+	})
+}
+
+// WriteHelmValues writes the values.yaml and values.schema.json of exporter
+// into the chart directory dir.
+func WriteHelmValues(exporter ConfigExporter, dir string, m mode.Mode, opts HelmOptions) error {
+	files := map[string]Formatter{
+		"values.yaml":        NewHelmValuesFormatter(opts),
+		"values.schema.json": NewHelmSchemaFormatter(opts),
+	}
+	for name, formatter := range files {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return errors.Wrapf(err, "create %s", name)
+		}
+		err = formatter.Format(f, exporter, m)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return errors.WithMessagef(err, "write %s", name)
+		}
+	}
+	return nil
+}
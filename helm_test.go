@@ -0,0 +1,79 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type HelmComponent struct {
+	Host    string `prop:"server.host:localhost"`
+	Port    int    `prop:"server.port,validate=lte=65535"`
+	Workers int    `prop:"server.workers:4" desc:"number of worker goroutines"`
+	Token   string `prop:"server.token:t0ken"`
+}
+
+func TestWriteHelmValues(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&HelmComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	assert.NoError(t, WriteHelmValues(exporter, dir, 0, HelmOptions{RootKey: "app.config"}))
+
+	values, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, `app:
+  config:
+    server:
+      host: localhost
+      port: 0
+      token: <set-me>
+      workers: 4
+`, string(values))
+
+	schema, err := os.ReadFile(filepath.Join(dir, "values.schema.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["app"],
+  "properties": {
+    "app": {
+      "type": "object",
+      "required": ["config"],
+      "properties": {
+        "config": {
+          "type": "object",
+          "required": ["server"],
+          "properties": {
+            "server": {
+              "type": "object",
+              "required": ["port"],
+              "properties": {
+                "host": {"type": "string", "default": "localhost"},
+                "port": {"type": "integer", "maximum": 65535},
+                "token": {"type": "string", "writeOnly": true},
+                "workers": {"type": "integer", "default": 4, "description": "number of worker goroutines"}
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`, string(schema))
+
+	t.Run("RevealSensitive", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, NewHelmValuesFormatter(HelmOptions{}).Format(buf, exporter, RevealSensitive))
+		assert.Contains(t, buf.String(), "    token: t0ken\n")
+	})
+}
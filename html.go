@@ -0,0 +1,58 @@
+package config_exporter
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/pkg/errors"
+	"html/template"
+	"io"
+)
+
+const FormatHTML = "html"
+
+var (
+	//go:embed assets/browser.html
+	browserHTML string
+	//go:embed assets/browser.css
+	browserCSS string
+	//go:embed assets/browser.js
+	browserJS string
+
+	browserTemplate = template.Must(template.New("browser").Parse(browserHTML))
+)
+
+type browserEntry struct {
+	ConfigEntry
+	Template any `json:"template,omitempty"`
+}
+
+func init() {
+	RegisterFormatter(FormatHTML, FormatterFunc(formatHTML))
+}
+
+// formatHTML renders a self-contained page browsing the configuration tree,
+// switching between the template values exported in mode m and the
+// effective values.
+func formatHTML(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+	var (
+		config  = exporter.GetConfig(m &^ (annotationModes | EffectiveValues))
+		entries []browserEntry
+	)
+	for _, entry := range exporter.Describe() {
+		value, _ := config.Get(entry.Key)
+		entries = append(entries, browserEntry{ConfigEntry: entry, Template: value})
+	}
+	// json escapes <, > and &, so the data can't close the script element
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "encode HTML browser data")
+	}
+	err = browserTemplate.Execute(w, map[string]any{
+		"Title": "Configuration",
+		"CSS":   template.CSS(browserCSS),
+		"JS":    template.JS(browserJS),
+		"Data":  template.JS(data),
+	})
+	return errors.Wrap(err, "execute HTML browser template")
+}
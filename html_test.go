@@ -0,0 +1,42 @@
+package config_exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+type BrowserComponent struct {
+	Host   string `prop:"server.host:localhost" desc:"</script><b>host</b>"`
+	Port   int    `prop:"server.port"`
+	Secret string `prop:"server.secret:s3cr3t"`
+}
+
+func TestHTML(t *testing.T) {
+	exporter, err := Export(app.LogError, app.SetComponents(&BrowserComponent{Port: 8080}))
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, exporter.Export(buf, FormatHTML, 0))
+	page := buf.String()
+
+	assert.Contains(t, page, "<style>body {")
+	assert.Contains(t, page, `document.getElementById("config-data")`)
+	assert.NotContains(t, page, "s3cr3t")
+	assert.NotContains(t, page, "</script><b>")
+
+	data := regexp.MustCompile(`(?s)<script id="config-data" type="application/json">(.*?)</script>`).FindStringSubmatch(page)
+	assert.Len(t, data, 2)
+	var entries []map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(data[1]), &entries))
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "server.host", entries[0]["key"])
+	assert.Equal(t, "</script><b>host</b>", entries[0]["description"])
+	assert.Equal(t, "server.port", entries[1]["key"])
+	assert.EqualValues(t, 0, entries[1]["template"])
+	assert.EqualValues(t, 8080, entries[1]["effective"])
+	assert.Equal(t, MaskedValue, entries[2]["template"])
+	assert.Equal(t, MaskedValue, entries[2]["effective"])
+}
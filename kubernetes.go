@@ -0,0 +1,146 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/properties"
+	"github.com/go-kid/strconv2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+const (
+	FormatKubernetes = "kubernetes"
+
+	// SecretPlaceholder stands for the sensitive values in the Secret and the
+	// Helm values rendered without RevealSensitive, so that deploying them
+	// never stores the mask.
+	SecretPlaceholder = "<set-me>"
+
+	defaultManifestName = "application"
+	defaultManifestFile = "application.yaml"
+)
+
+type KubernetesOptions struct {
+	// Name of the ConfigMap and the Secret, "application" by default.
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// Flatten renders every key as an entry of the manifests rather than
+	// embedding the configuration as the single file FileName.
+	Flatten bool
+	// FileName of the embedded configuration, "application.yaml" by default.
+	FileName string
+}
+
+type k8sMetadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type k8sManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+}
+
+func init() {
+	RegisterFormatter(FormatKubernetes, NewKubernetesFormatter(KubernetesOptions{}))
+	RegisterFormatter("k8s", NewKubernetesFormatter(KubernetesOptions{}))
+}
+
+// NewKubernetesFormatter renders the configuration as a ConfigMap holding the
+// non-sensitive keys, followed by a Secret holding the sensitive ones when
+// there are any. Unless the mode has RevealSensitive, the Secret holds
+// SecretPlaceholder in place of the values, to be filled in before applying.
+func NewKubernetesFormatter(opts KubernetesOptions) Formatter {
+	if opts.Name == "" {
+		opts.Name = defaultManifestName
+	}
+	if opts.FileName == "" {
+		opts.FileName = defaultManifestFile
+	}
+	return FormatterFunc(func(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+		var (
+			sensitiveKeys = sensitiveKeysOf(exporter)
+			plain         = properties.New()
+			secret        = properties.New()
+		)
+		for _, set := range exporter.GetConfig(m &^ annotationModes).ValueSets() {
+			if isBound(set.Key, sensitiveKeys) {
+				if !m.Eq(RevealSensitive) {
+					set.Value = SecretPlaceholder
+				}
+				secret.Set(set.Key, set.Value)
+			} else {
+				plain.Set(set.Key, set.Value)
+			}
+		}
+
+		configMap, err := opts.manifestData(plain)
+		if err != nil {
+			return err
+		}
+		manifests := []k8sManifest{{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Metadata:   opts.metadata(),
+			Data:       configMap,
+		}}
+		if len(secret) != 0 {
+			secretData, err := opts.manifestData(secret)
+			if err != nil {
+				return err
+			}
+			manifests = append(manifests, k8sManifest{
+				APIVersion: "v1",
+				Kind:       "Secret",
+				Metadata:   opts.metadata(),
+				Type:       "Opaque",
+				StringData: secretData,
+			})
+		}
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		for _, manifest := range manifests {
+			if err := encoder.Encode(manifest); err != nil {
+				return errors.Wrapf(err, "encode %s manifest", manifest.Kind)
+			}
+		}
+		return encoder.Close()
+	})
+}
+
+func (opts KubernetesOptions) metadata() k8sMetadata {
+	return k8sMetadata{
+		Name:      opts.Name,
+		Namespace: opts.Namespace,
+		Labels:    opts.Labels,
+	}
+}
+
+func (opts KubernetesOptions) manifestData(p properties.Properties) (map[string]string, error) {
+	data := make(map[string]string)
+	if !opts.Flatten {
+		buf := &bytes.Buffer{}
+		if err := formatYAML(buf, p); err != nil {
+			return nil, err
+		}
+		data[opts.FileName] = buf.String()
+		return data, nil
+	}
+	for _, set := range p.ValueSets() {
+		val, err := strconv2.FormatAny(set.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "format value of '%s'", set.Key)
+		}
+		data[set.Key] = val
+	}
+	return data, nil
+}
@@ -0,0 +1,78 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKubernetesFormatter(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&SensitiveComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	t.Run("Embedded", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, exporter.Export(buf, FormatKubernetes, 0))
+		assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: application
+data:
+  application.yaml: |
+    zoo:
+        monkey: george
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: application
+type: Opaque
+stringData:
+  application.yaml: |
+    db:
+        apiKey: <set-me>
+        password: <set-me>
+    tls:
+        cert: <set-me>
+`, buf.String())
+	})
+	t.Run("Flatten", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		formatter := NewKubernetesFormatter(KubernetesOptions{
+			Name:      "shop",
+			Namespace: "prod",
+			Labels:    map[string]string{"app": "shop"},
+			Flatten:   true,
+		})
+		assert.NoError(t, formatter.Format(buf, exporter, RevealSensitive))
+		assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shop
+  namespace: prod
+  labels:
+    app: shop
+data:
+  zoo.monkey: george
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: shop
+  namespace: prod
+  labels:
+    app: shop
+type: Opaque
+stringData:
+  db.apiKey: abc
+  db.password: p@ss
+  tls.cert: pem
+`, buf.String())
+	})
+}
@@ -0,0 +1,71 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/strconv2"
+	"io"
+	"strings"
+)
+
+const FormatMarkdown = "markdown"
+
+func init() {
+	RegisterFormatter(FormatMarkdown, FormatterFunc(formatMarkdown))
+	RegisterFormatter("md", FormatterFunc(formatMarkdown))
+}
+
+// formatMarkdown renders the Describe entries as a reference document with a
+// table per top-level key.
+func formatMarkdown(w io.Writer, exporter ConfigExporter, m mode.Mode) error {
+	sb := &strings.Builder{}
+	sb.WriteString("# Configuration reference\n")
+	var group string
+	for _, entry := range exporter.Describe() {
+		if g, _, _ := strings.Cut(entry.Key, "."); g != group {
+			group = g
+			fmt.Fprintf(sb, "\n## %s\n\n", group)
+			sb.WriteString("| Key | Type | Default | Required | Validation | Description | Components |\n")
+			sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+		}
+		var def string
+		if entry.HasDefault {
+			s, err := strconv2.FormatAny(entry.Default)
+			if err != nil {
+				s = fmt.Sprint(entry.Default)
+			}
+			def = markdownCode(s)
+		}
+		required := "no"
+		if entry.Required {
+			required = "yes"
+		}
+		var components []string
+		for _, component := range entry.Components {
+			components = append(components, markdownCode(component))
+		}
+		fmt.Fprintf(sb, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			markdownCode(entry.Key),
+			markdownCode(entry.Type),
+			def,
+			required,
+			markdownCode(strings.Join(entry.Validate, " ")),
+			markdownCell(entry.Description),
+			strings.Join(components, "<br>"),
+		)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "<br>")
+}
@@ -0,0 +1,32 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type MarkdownComponent struct {
+	Host  string   `prop:"server.host:localhost" desc:"listen host"`
+	Port  int      `prop:"server.port,validate=min=1 max=65535"`
+	Modes []string `prop:"app.modes:[a,b]" desc:"enabled modes, a | b"`
+}
+
+func TestMarkdown(t *testing.T) {
+	exporter, err := Export(app.LogError, app.SetComponents(&MarkdownComponent{}))
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, exporter.Export(buf, FormatMarkdown, 0))
+	assert.Equal(t, "# Configuration reference\n"+
+		"\n## app\n\n"+
+		"| Key | Type | Default | Required | Validation | Description | Components |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| `app.modes` | `[]string` | `[\"a\",\"b\"]` | no |  | enabled modes, a \\| b | `github.com/go-kid/config-exporter/MarkdownComponent` |\n"+
+		"\n## server\n\n"+
+		"| Key | Type | Default | Required | Validation | Description | Components |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| `server.host` | `string` | `localhost` | no |  | listen host | `github.com/go-kid/config-exporter/MarkdownComponent` |\n"+
+		"| `server.port` | `int` |  | yes | `min=1 max=65535` |  | `github.com/go-kid/config-exporter/MarkdownComponent` |\n",
+		buf.String())
+}
@@ -0,0 +1,180 @@
+package config_exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/properties"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// OrderedConfig is an order-preserving tree of exported configuration. It
+// marshals to YAML and JSON with the keys in the order of NewOrderedConfig.
+type OrderedConfig struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewOrderedConfig orders the keys of p by their first position in order,
+// or the position of the first key nested in them. Annotations follow their
+// key and keys absent from order come last, alphabetically.
+func NewOrderedConfig(p properties.Properties, order []string) *OrderedConfig {
+	ranks := make(map[string]int)
+	for i, key := range order {
+		segments := strings.Split(strings.ToLower(key), ".")
+		for j := range segments {
+			path := strings.Join(segments[:j+1], ".")
+			if _, ok := ranks[path]; !ok {
+				ranks[path] = i
+			}
+		}
+	}
+	return newOrderedConfig(p, "", ranks)
+}
+
+func newOrderedConfig(m map[string]any, path string, ranks map[string]int) *OrderedConfig {
+	c := &OrderedConfig{
+		keys:   make([]string, 0, len(m)),
+		values: make(map[string]any, len(m)),
+	}
+	rankOf := func(key string) int {
+		key, _, _ = strings.Cut(key, "@")
+		if rank, ok := ranks[strings.ToLower(joinKey(path, key))]; ok {
+			return rank
+		}
+		return len(ranks)
+	}
+	for key, value := range m {
+		c.keys = append(c.keys, key)
+		switch sub := value.(type) {
+		case map[string]any:
+			value = newOrderedConfig(sub, joinKey(path, key), ranks)
+		case properties.Properties:
+			value = newOrderedConfig(sub, joinKey(path, key), ranks)
+		}
+		c.values[key] = value
+	}
+	sort.Slice(c.keys, func(i, j int) bool {
+		if ri, rj := rankOf(c.keys[i]), rankOf(c.keys[j]); ri != rj {
+			return ri < rj
+		}
+		return c.keys[i] < c.keys[j]
+	})
+	return c
+}
+
+// ValueSets flattens c into dotted keys, keeping the order of c.
+func (c *OrderedConfig) ValueSets() []*properties.ValueSet {
+	var sets []*properties.ValueSet
+	for _, key := range c.keys {
+		if sub, ok := c.values[key].(*OrderedConfig); ok {
+			for _, set := range sub.ValueSets() {
+				sets = append(sets, &properties.ValueSet{Key: joinKey(key, set.Key), Value: set.Value})
+			}
+			continue
+		}
+		sets = append(sets, &properties.ValueSet{Key: key, Value: c.values[key]})
+	}
+	return sets
+}
+
+func (c *OrderedConfig) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range c.keys {
+		value := &yaml.Node{}
+		if err := value.Encode(c.values[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+	return node, nil
+}
+
+func (c *OrderedConfig) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range c.keys {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(c.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// recordDeclarationOrder numbers the configuration properties of m in the
+// order their fields are declared.
+func (d *postProcessor) recordDeclarationOrder(m *component_definition.Meta) {
+	configurations := m.GetConfigurationProperties()
+	for _, field := range m.Fields {
+		for _, prop := range configurations {
+			if prop.Field == field {
+				d.declarationOrder[prop.ID()] = len(d.declarationOrder)
+			}
+		}
+	}
+}
+
+// orderedConfig orders p by the KeyOrder of exporter. Exporters that don't
+// record the declaration order leave p as it is.
+func orderedConfig(exporter ConfigExporter, p properties.Properties) ExportedConfig {
+	if orderer, ok := exporter.(interface{ KeyOrder() []string }); ok {
+		return NewOrderedConfig(p, orderer.KeyOrder())
+	}
+	return p
+}
+
+// KeyOrder returns the bound keys in the order components, their fields and
+// the quotes of their tags are declared.
+func (d *postProcessor) KeyOrder() []string {
+	props := make([]*component_definition.Property, len(d.properties))
+	copy(props, d.properties)
+	sort.SliceStable(props, func(i, j int) bool {
+		return d.declarationOrder[props[i].ID()] < d.declarationOrder[props[j].ID()]
+	})
+	var keys []string
+	for _, property := range props {
+		if property.Tag == definition.PrefixTag {
+			keys = append(keys, structKeys(property.Type, mapperOf(property), property.TagVal, make(map[reflect.Type]bool))...)
+			continue
+		}
+		for _, content := range quoteHelper.FindAllContent(property.TagStr) {
+			key, _, _ := strings.Cut(content, ":")
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func structKeys(t reflect.Type, mapper, prefix string, visited map[reflect.Type]bool) []string {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct || t == durationType || isTextType(t) || visited[t] {
+		return []string{prefix}
+	}
+	visited[t] = true
+	defer delete(visited, t)
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get(mapper) == "-" {
+			continue
+		}
+		keys = append(keys, structKeys(field.Type, mapper, joinKey(prefix, fieldName(field, mapper)), visited)...)
+	}
+	return keys
+}
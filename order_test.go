@@ -0,0 +1,126 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type OrderedServer struct {
+	Port    int      `yaml:"port"`
+	Host    string   `yaml:"host"`
+	Aliases []string `yaml:"aliases"`
+}
+
+func (o *OrderedServer) Prefix() string {
+	return "zeta.server"
+}
+
+type OrderedComponent struct {
+	Name    string `value:"${zeta.name:svc}"`
+	Server  *OrderedServer
+	Timeout int    `value:"${alpha.timeout:30}"`
+	Mode    string `value:"${zeta.mode:dev} ${zeta.level:info}"`
+}
+
+func TestPreserveOrder(t *testing.T) {
+	exporter, err := Export(app.SetComponents(&OrderedComponent{}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"zeta.name",
+		"zeta.server.port", "zeta.server.host", "zeta.server.aliases",
+		"alpha.timeout",
+		"zeta.mode", "zeta.level",
+	}, exporter.(*postProcessor).KeyOrder())
+
+	var tests = []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatYAML,
+			want: `zeta:
+    name: svc
+    server:
+        port: 0
+        host: string
+        aliases:
+            - string
+    mode: dev
+    level: info
+alpha:
+    timeout: 30
+`,
+		},
+		{
+			format: FormatJSON,
+			want: `{
+  "zeta": {
+    "name": "svc",
+    "server": {
+      "port": 0,
+      "host": "string",
+      "aliases": [
+        "string"
+      ]
+    },
+    "mode": "dev",
+    "level": "info"
+  },
+  "alpha": {
+    "timeout": 30
+  }
+}
+`,
+		},
+		{
+			format: FormatDotenv,
+			want: `ZETA_NAME=svc
+ZETA_SERVER_PORT=0
+ZETA_SERVER_HOST=string
+ZETA_SERVER_ALIASES="[\"string\"]"
+ZETA_MODE=dev
+ZETA_LEVEL=info
+ALPHA_TIMEOUT=30
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := exporter.Export(buf, tt.format, PreserveOrder)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String(), buf.String())
+		})
+	}
+
+	t.Run("Annotations", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := exporter.Export(buf, FormatYAML, PreserveOrder|AnnotationEnv)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `alpha:
+    timeout: 30
+    timeout@Env: ALPHA_TIMEOUT
+`)
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := exporter.Export(buf, FormatYAML, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, `alpha:
+    timeout: 30
+zeta:
+    level: info
+    mode: dev
+    name: svc
+    server:
+        aliases:
+            - string
+        host: string
+        port: 0
+`, buf.String())
+	})
+}
@@ -0,0 +1,167 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/configure"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/go-kid/ioc/definition"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/go-kid/properties"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"sync"
+)
+
+const (
+	OriginDefault     = "default"
+	OriginExample     = "example"
+	OriginPlaceholder = "placeholder"
+	OriginComponent   = "component"
+	// OriginLoader is reported for loaded keys whose loader isn't recorded.
+	OriginLoader = "loader"
+)
+
+type loadedKey struct {
+	origin string
+	seq    int
+}
+
+type originConfigure struct {
+	configure.Configure
+	mu   sync.Mutex
+	seq  int
+	keys map[string]loadedKey
+}
+
+// RecordOrigins returns a SettingOption recording which config loader
+// supplies each key, as reported by the AnnotationOrigin mode. It wraps the
+// loaders set after it, so it must precede the options setting them.
+func RecordOrigins() app.SettingOption {
+	return func(s *app.App) {
+		s.Configure = &originConfigure{
+			Configure: s.Configure,
+			keys:      make(map[string]loadedKey),
+		}
+	}
+}
+
+func (c *originConfigure) AddLoaders(loaders ...configure.Loader) {
+	c.Configure.AddLoaders(c.wrap(loaders)...)
+}
+
+func (c *originConfigure) SetLoaders(loaders ...configure.Loader) {
+	c.Configure.SetLoaders(c.wrap(loaders)...)
+}
+
+func (c *originConfigure) wrap(loaders []configure.Loader) []configure.Loader {
+	wrapped := make([]configure.Loader, len(loaders))
+	for i, l := range loaders {
+		ol := &originLoader{Loader: l, configure: c}
+		if _, ok := l.(definition.Ordered); !ok {
+			wrapped[i] = ol
+		} else if _, ok := l.(definition.Priority); !ok {
+			wrapped[i] = &orderedOriginLoader{ol}
+		} else {
+			wrapped[i] = &priorityOrderedOriginLoader{&orderedOriginLoader{ol}}
+		}
+	}
+	return wrapped
+}
+
+func (c *originConfigure) record(origin string, config []byte) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(config, &raw); err != nil {
+		return
+	}
+	p, err := properties.NewFromAny(raw)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	for key := range p.ToPropertiesMap() {
+		c.keys[strings.ToLower(key)] = loadedKey{origin: origin, seq: c.seq}
+	}
+}
+
+// originOf returns the origin of the last loader supplying key or any key
+// nested in it, as later loaders override earlier ones.
+func (c *originConfigure) originOf(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key = strings.ToLower(key)
+	var found loadedKey
+	for k, loaded := range c.keys {
+		if (k == key || strings.HasPrefix(k, key+".")) && loaded.seq > found.seq {
+			found = loaded
+		}
+	}
+	if found.origin == "" {
+		return OriginLoader
+	}
+	return found.origin
+}
+
+type originLoader struct {
+	configure.Loader
+	configure *originConfigure
+}
+
+func (l *originLoader) LoadConfig() ([]byte, error) {
+	config, err := l.Loader.LoadConfig()
+	if err == nil && len(config) != 0 {
+		l.configure.record(loaderOrigin(l.Loader), config)
+	}
+	return config, err
+}
+
+type orderedOriginLoader struct {
+	*originLoader
+}
+
+func (l *orderedOriginLoader) Order() int {
+	return l.Loader.(definition.Ordered).Order()
+}
+
+type priorityOrderedOriginLoader struct {
+	*orderedOriginLoader
+}
+
+func (l *priorityOrderedOriginLoader) Priority() {}
+
+func loaderOrigin(l configure.Loader) string {
+	switch l := l.(type) {
+	case loader.FileLoader:
+		return "file:" + string(l)
+	case loader.ArgsLoader:
+		return "args"
+	case loader.RawLoader:
+		return "raw"
+	case fmt.Stringer:
+		return l.String()
+	default:
+		return fmt.Sprintf("%T", l)
+	}
+}
+
+func (d *postProcessor) originOf(property *component_definition.Property, key string, m mode.Mode) string {
+	if d.configure != nil && d.configure.Get(key) != nil {
+		if c, ok := d.configure.(*originConfigure); ok {
+			return c.originOf(key)
+		}
+		return OriginLoader
+	}
+	if _, ok := defaultOf(property, bindingOf(property, key), key); ok {
+		return OriginDefault
+	}
+	if m.Eq(EffectiveValues) {
+		return OriginComponent
+	}
+	if _, ok := exampleOf(property, key); ok {
+		return OriginExample
+	}
+	return OriginPlaceholder
+}
@@ -0,0 +1,85 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type ProvenanceComponent struct {
+	Host    string   `prop:"server.host"`
+	Port    int      `prop:"server.port:8080"`
+	Tags    []string `prop:"server.tags"`
+	Mode    string   `prop:"server.mode"`
+	Timeout string   `prop:"server.timeout"`
+}
+
+func TestOrigin(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(`
+server:
+    host: file.example.com
+    tags: [a, b]
+    timeout: 1s
+`), 0644))
+
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		RecordOrigins(),
+		app.SetComponents(&ProvenanceComponent{}, exporter),
+		app.AddConfigLoader(
+			loader.NewRawLoader([]byte(`
+server:
+    host: raw.example.com
+`)),
+			loader.NewFileLoader(file),
+		),
+	)
+	assert.NoError(t, err)
+
+	t.Run("Annotation", func(t *testing.T) {
+		bytes, err := yaml.Marshal(exporter.GetConfig(AnnotationOrigin))
+		assert.NoError(t, err)
+		assert.Equal(t, `server:
+    host: raw.example.com
+    host@Origin: raw
+    mode: string
+    mode@Origin: placeholder
+    port: 8080
+    port@Origin: default
+    tags:
+        - a
+        - b
+    tags@Origin: file:`+file+`
+    timeout: 1s
+    timeout@Origin: file:`+file+`
+`, string(bytes))
+	})
+	t.Run("Describe", func(t *testing.T) {
+		entries := exporter.Describe()
+		assert.Equal(t, "raw", findEntry(entries, "server.host").Origin)
+		assert.Equal(t, OriginDefault, findEntry(entries, "server.port").Origin)
+		assert.Equal(t, OriginPlaceholder, findEntry(entries, "server.mode").Origin)
+	})
+	t.Run("Effective", func(t *testing.T) {
+		origins := exporter.GetConfig(AnnotationOrigin | EffectiveValues)
+		origin, _ := origins.Get("server.mode@Origin")
+		assert.Equal(t, OriginComponent, origin)
+	})
+	t.Run("Unrecorded", func(t *testing.T) {
+		exporter := NewConfigExporter()
+		_, err := ioc.Run(
+			app.LogError,
+			app.SetComponents(&ProvenanceComponent{}, exporter),
+			app.AddConfigLoader(loader.NewFileLoader(file)),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, OriginLoader, findEntry(exporter.Describe(), "server.host").Origin)
+	})
+}
@@ -0,0 +1,160 @@
+package config_exporter
+
+import (
+	"encoding"
+	"fmt"
+	"github.com/go-kid/ioc/component_definition"
+	"github.com/go-kid/ioc/util/mode"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	ipType            = reflect.TypeOf(net.IP{})
+	urlType           = reflect.TypeOf(url.URL{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	exampleTime = time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+)
+
+const timeLayoutArg component_definition.ArgType = "timeLayout"
+
+// isTextType reports whether values of t are written as a single string
+// rather than walked field by field: url.URL and the encoding.TextMarshaler
+// implementations such as time.Time and net.IP.
+func isTextType(t reflect.Type) bool {
+	t = indirectType(t)
+	return t == urlType || t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// textValue returns the text form of a value of a text type, or "" when it
+// can't be marshaled.
+func textValue(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	switch a := ptr.Interface().(type) {
+	case *url.URL:
+		return a.String()
+	case encoding.TextMarshaler:
+		text, err := a.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	}
+	return ""
+}
+
+// collapseTextFields replaces the fields of text types that toMap walked into
+// maps, or left as raw values, with their text form.
+func collapseTextFields(raw map[string]any, v reflect.Value, mapper string) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := fieldName(field, mapper)
+		if _, ok := raw[name]; !ok {
+			continue
+		}
+		if isTextType(field.Type) {
+			raw[name] = textValue(v.Field(i))
+		} else if sub, ok := raw[name].(map[string]any); ok {
+			collapseTextFields(sub, v.Field(i), mapper)
+		}
+	}
+}
+
+// placeholderOf returns the value exported for key in place of the zero value
+// placeholder, as chosen by the TypedPlaceholders and ExamplePlaceholders modes.
+func placeholderOf(property *component_definition.Property, key string, m mode.Mode) (any, bool) {
+	t := keyType(property, key)
+	if t == nil {
+		return nil, false
+	}
+	if m.Eq(ExamplePlaceholders) {
+		var layout = time.RFC3339
+		if layouts, ok := property.Args().Find(timeLayoutArg); ok && len(layouts) != 0 {
+			layout = layouts[0]
+		}
+		if example, ok := typeExample(t, layout); ok {
+			return example, true
+		}
+	}
+	if m.Eq(TypedPlaceholders) {
+		return "<" + placeholderTypeName(t) + ">", true
+	}
+	return nil, false
+}
+
+// typeExample returns a realistic value of the well-known types, or of slices
+// of them.
+func typeExample(t reflect.Type, layout string) (any, bool) {
+	t = indirectType(t)
+	switch t {
+	case durationType:
+		return "30s", true
+	case timeType:
+		return exampleTime.Format(layout), true
+	case ipType:
+		return "127.0.0.1", true
+	case urlType:
+		return "https://example.com", true
+	}
+	if isTextType(t) {
+		if text, ok := textValue(reflect.New(t)).(string); ok && text != "" {
+			return text, true
+		}
+		return nil, false
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		if example, ok := typeExample(t.Elem(), layout); ok {
+			return []any{example}, true
+		}
+	}
+	return nil, false
+}
+
+// placeholderTypeName names t the way typed placeholders show it, e.g.
+// "duration", "[]float64" or "map[string]ip".
+func placeholderTypeName(t reflect.Type) string {
+	switch t {
+	case durationType:
+		return "duration"
+	case timeType:
+		return "time"
+	case ipType:
+		return "ip"
+	case urlType:
+		return "url"
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return placeholderTypeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + placeholderTypeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), placeholderTypeName(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", placeholderTypeName(t.Key()), placeholderTypeName(t.Elem()))
+	}
+	return t.String()
+}
@@ -0,0 +1,135 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type EndpointConfig struct {
+	URL     *url.URL      `yaml:"url"`
+	Addr    net.IP        `yaml:"addr"`
+	Timeout time.Duration `yaml:"timeout"`
+	Weights []float64     `yaml:"weights"`
+	Name    string        `yaml:"name"`
+}
+
+type PlaceholderComponent struct {
+	Endpoint *EndpointConfig `prefix:"endpoint"`
+	Since    time.Time       `prop:"endpoint.since,timeLayout=2006-01-02"`
+	Labels   map[string]int  `prop:"endpoint.labels"`
+	Retries  int             `prop:"endpoint.retries:3"`
+}
+
+func TestPlaceholders(t *testing.T) {
+	exporter, err := Export(
+		app.SetComponents(&PlaceholderComponent{}),
+		app.SetConfigLoader(loader.NewRawLoader([]byte(`
+endpoint:
+    name: api
+`))),
+	)
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		name   string
+		format string
+		mode   mode.Mode
+		want   string
+	}{
+		{
+			name:   "Typed",
+			format: FormatYAML,
+			mode:   TypedPlaceholders,
+			want: `endpoint:
+    addr: <ip>
+    labels: <map[string]int>
+    name: api
+    retries: 3
+    since: <time>
+    timeout: <duration>
+    url: <url>
+    weights: <[]float64>
+`,
+		},
+		{
+			name:   "Examples",
+			format: FormatYAML,
+			mode:   TypedPlaceholders | ExamplePlaceholders,
+			want: `endpoint:
+    addr: 127.0.0.1
+    labels: <map[string]int>
+    name: api
+    retries: 3
+    since: "2006-01-02"
+    timeout: 30s
+    url: https://example.com
+    weights: <[]float64>
+`,
+		},
+		{
+			name:   "Commented",
+			format: FormatCommentedYAML,
+			mode:   TypedPlaceholders | CommentPlaceholders | AnnotationEnv,
+			want: `endpoint:
+    # @Env: ENDPOINT_ADDR
+    # addr: <ip>
+    # @Env: ENDPOINT_LABELS
+    # labels: <map[string]int>
+    # @Env: ENDPOINT_NAME
+    name: api
+    # @Env: ENDPOINT_RETRIES
+    retries: 3 # default: 3
+    # @Env: ENDPOINT_SINCE
+    # since: <time>
+    # @Env: ENDPOINT_TIMEOUT
+    # timeout: <duration>
+    # @Env: ENDPOINT_URL
+    # url: <url>
+    # @Env: ENDPOINT_WEIGHTS
+    # weights: <[]float64>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := exporter.Export(buf, tt.format, tt.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String(), buf.String())
+		})
+	}
+
+	t.Run("CommentedMapping", func(t *testing.T) {
+		exporter, err := Export(app.SetComponents(&PlaceholderComponent{}))
+		assert.NoError(t, err)
+		buf := &bytes.Buffer{}
+		err = exporter.Export(buf, FormatCommentedYAML, ExamplePlaceholders|CommentPlaceholders)
+		assert.NoError(t, err)
+		assert.Equal(t, `endpoint:
+    # addr: 127.0.0.1
+    # labels:
+    #     string: 0
+    # name: string
+    retries: 3 # default: 3
+    # since: "2006-01-02"
+    # timeout: 30s
+    # url: https://example.com
+    # weights:
+    #     - 0
+`, buf.String())
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		schema := exporter.JSONSchema()
+		endpoint := schema.Properties["endpoint"]
+		assert.Equal(t, "string", endpoint.Properties["url"].Type)
+		assert.Equal(t, "string", endpoint.Properties["since"].Type)
+	})
+}
@@ -0,0 +1,284 @@
+package config_exporter
+
+import (
+	"encoding/json"
+	"github.com/go-kid/ioc/util/mode"
+	"github.com/pkg/errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatJSONSchema = "jsonschema"
+	JSONSchemaDraft  = "https://json-schema.org/draft/2020-12/schema"
+)
+
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *float64               `json:"minLength,omitempty"`
+	MaxLength            *float64               `json:"maxLength,omitempty"`
+	MinItems             *float64               `json:"minItems,omitempty"`
+	MaxItems             *float64               `json:"maxItems,omitempty"`
+	MinProperties        *float64               `json:"minProperties,omitempty"`
+	MaxProperties        *float64               `json:"maxProperties,omitempty"`
+}
+
+func init() {
+	RegisterFormatter(FormatJSONSchema, FormatterFunc(func(w io.Writer, exporter ConfigExporter, mode mode.Mode) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(exporter.JSONSchema()); err != nil {
+			return errors.Wrap(err, "encode JSON schema")
+		}
+		return nil
+	}))
+}
+
+func (d *postProcessor) JSONSchema() *JSONSchema {
+	return NewJSONSchema(d.Describe())
+}
+
+// NewJSONSchema builds an object schema from entries, nesting properties
+// along the dotted key paths.
+func NewJSONSchema(entries []ConfigEntry) *JSONSchema {
+	root := &JSONSchema{
+		Schema: JSONSchemaDraft,
+		Type:   "object",
+	}
+	for _, entry := range entries {
+		path := strings.Split(entry.Key, ".")
+		parent := root.object(path[:len(path)-1])
+		leaf := entrySchema(entry)
+		if exist, ok := parent.Properties[path[len(path)-1]]; ok && exist.Properties != nil {
+			for name, sub := range exist.Properties {
+				leaf.setProperty(name, sub)
+			}
+		}
+		parent.setProperty(path[len(path)-1], leaf)
+		if entry.Required {
+			root.require(strings.Split(entry.Binding, "."))
+		}
+	}
+	return root
+}
+
+func (s *JSONSchema) setProperty(name string, schema *JSONSchema) {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*JSONSchema)
+	}
+	s.Properties[name] = schema
+}
+
+func (s *JSONSchema) object(path []string) *JSONSchema {
+	node := s
+	for _, seg := range path {
+		child, ok := node.Properties[seg]
+		if !ok {
+			child = &JSONSchema{Type: "object"}
+			node.setProperty(seg, child)
+		}
+		node = child
+	}
+	return node
+}
+
+func (s *JSONSchema) require(path []string) {
+	node := s
+	for _, seg := range path {
+		if !contains(node.Required, seg) {
+			node.Required = append(node.Required, seg)
+		}
+		child, ok := node.Properties[seg]
+		if !ok {
+			return
+		}
+		node = child
+	}
+}
+
+func entrySchema(entry ConfigEntry) *JSONSchema {
+	var schema *JSONSchema
+	if entry.goType != nil {
+		schema = typeSchema(entry.goType, entry.Mapper, make(map[reflect.Type]bool))
+	} else {
+		schema = kindSchema(entry.Kind)
+	}
+	schema.Description = entry.Description
+	schema.WriteOnly = entry.Sensitive
+	if entry.HasDefault && !entry.Sensitive {
+		schema.Default = entry.Default
+	}
+	for _, rule := range entry.Validate {
+		schema.applyRule(rule)
+	}
+	return schema
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func typeSchema(t reflect.Type, mapper string, visited map[reflect.Type]bool) *JSONSchema {
+	t = indirectType(t)
+	if t == durationType {
+		return &JSONSchema{Type: []string{"string", "integer"}}
+	}
+	if isTextType(t) {
+		return &JSONSchema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		schema := &JSONSchema{Type: "array", Items: typeSchema(t.Elem(), mapper, visited)}
+		if t.Kind() == reflect.Array {
+			schema.MaxItems = float(float64(t.Len()))
+		}
+		return schema
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: typeSchema(t.Elem(), mapper, visited)}
+	case reflect.Struct:
+		schema := &JSONSchema{Type: "object"}
+		if visited[t] {
+			return schema
+		}
+		visited[t] = true
+		defer delete(visited, t)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get(mapper) == "-" {
+				continue
+			}
+			sub := typeSchema(field.Type, mapper, visited)
+			sub.Description = field.Tag.Get(DescriptionTag)
+			schema.setProperty(fieldName(field, mapper), sub)
+		}
+		return schema
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer", Minimum: float(0)}
+	default:
+		return kindSchema(t.Kind().String())
+	}
+}
+
+func kindSchema(kind string) *JSONSchema {
+	switch kind {
+	case "bool":
+		return &JSONSchema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return &JSONSchema{Type: "integer"}
+	case "float32", "float64":
+		return &JSONSchema{Type: "number"}
+	case "string":
+		return &JSONSchema{Type: "string"}
+	case "slice", "array":
+		return &JSONSchema{Type: "array"}
+	case "map", "struct":
+		return &JSONSchema{Type: "object"}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// applyRule translates a go-playground/validator rule into the matching
+// JSON schema constraint, ignoring rules that have no counterpart.
+func (s *JSONSchema) applyRule(rule string) {
+	name, param, _ := strings.Cut(rule, "=")
+	switch name {
+	case "oneof":
+		for _, v := range strings.Fields(param) {
+			s.Enum = append(s.Enum, s.typedValue(v))
+		}
+		return
+	case "eq":
+		s.Const = s.typedValue(param)
+		return
+	}
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch name {
+	case "len":
+		s.setMin(n)
+		s.setMax(n)
+	case "min", "gte":
+		s.setMin(n)
+	case "max", "lte":
+		s.setMax(n)
+	case "gt":
+		if s.isType("integer", "number") {
+			s.ExclusiveMinimum = float(n)
+		} else {
+			s.setMin(n + 1)
+		}
+	case "lt":
+		if s.isType("integer", "number") {
+			s.ExclusiveMaximum = float(n)
+		} else {
+			s.setMax(n - 1)
+		}
+	}
+}
+
+func (s *JSONSchema) setMin(n float64) {
+	switch {
+	case s.isType("string"):
+		s.MinLength = float(n)
+	case s.isType("array"):
+		s.MinItems = float(n)
+	case s.isType("object"):
+		s.MinProperties = float(n)
+	default:
+		s.Minimum = float(n)
+	}
+}
+
+func (s *JSONSchema) setMax(n float64) {
+	switch {
+	case s.isType("string"):
+		s.MaxLength = float(n)
+	case s.isType("array"):
+		s.MaxItems = float(n)
+	case s.isType("object"):
+		s.MaxProperties = float(n)
+	default:
+		s.Maximum = float(n)
+	}
+}
+
+func (s *JSONSchema) isType(types ...string) bool {
+	t, ok := s.Type.(string)
+	return ok && contains(types, t)
+}
+
+func (s *JSONSchema) typedValue(v string) any {
+	if s.isType("integer", "number") {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	if s.isType("boolean") {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+func float(n float64) *float64 {
+	return &n
+}
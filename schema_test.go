@@ -0,0 +1,103 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type PoolConfig struct {
+	Size    uint          `yaml:"size"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func (c *PoolConfig) Prefix() string {
+	return "db.pool"
+}
+
+type SchemaComponent struct {
+	Host  string      `prop:"db.host,validate=min=3 max=64"`
+	Port  int         `prop:"db.port:5432,validate=gt=0 lte=65535"`
+	Mode  string      `prop:"db.mode:rw,validate=oneof=rw ro"`
+	Hosts []string    `prop:"db.replicas,validate=min=1 max=5"`
+	Pool  *PoolConfig `prefix:"db.pool"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&SchemaComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	err = exporter.Export(buf, FormatJSONSchema, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "db": {
+      "type": "object",
+      "properties": {
+        "host": {
+          "type": "string",
+          "minLength": 3,
+          "maxLength": 64
+        },
+        "mode": {
+          "type": "string",
+          "default": "rw",
+          "enum": [
+            "rw",
+            "ro"
+          ]
+        },
+        "pool": {
+          "type": "object",
+          "properties": {
+            "size": {
+              "type": "integer",
+              "minimum": 0
+            },
+            "timeout": {
+              "type": [
+                "string",
+                "integer"
+              ]
+            }
+          }
+        },
+        "port": {
+          "type": "integer",
+          "default": 5432,
+          "maximum": 65535,
+          "exclusiveMinimum": 0
+        },
+        "replicas": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "minItems": 1,
+          "maxItems": 5
+        }
+      },
+      "required": [
+        "host",
+        "pool",
+        "replicas"
+      ]
+    }
+  },
+  "required": [
+    "db"
+  ]
+}
+`, buf.String(), buf.String())
+	// ioc splits the rules on spaces, the values of oneof included
+	assert.Equal(t, []string{"oneof=rw ro"}, findEntry(exporter.Describe(), "db.mode").Validate)
+}
@@ -0,0 +1,69 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc/component_definition"
+	"strings"
+	"unicode"
+)
+
+const (
+	ArgSensitive component_definition.ArgType = "Sensitive"
+	MaskedValue                               = "******"
+)
+
+var sensitiveKeywords = []string{"password", "passwd", "secret", "token", "credential"}
+
+// sensitiveKeysOf lists the keys exporter describes as sensitive.
+func sensitiveKeysOf(exporter ConfigExporter) []string {
+	var keys []string
+	for _, entry := range exporter.Describe() {
+		if entry.Sensitive {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+func isSensitive(property *component_definition.Property, key string) bool {
+	return property.Args().Has(ArgSensitive) || isSensitiveKey(key)
+}
+
+// isSensitiveKey guesses from the last segment of key whether it holds a
+// secret. "key" only matches as a whole word, e.g. "apiKey" but not "monkey".
+func isSensitiveKey(key string) bool {
+	name := key[strings.LastIndex(key, ".")+1:]
+	lower := strings.ToLower(name)
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return contains(splitWords(name), "key")
+}
+
+func splitWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) != 0 {
+				words = append(words, strings.ToLower(string(word)))
+				word = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) != 0 &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			words = append(words, strings.ToLower(string(word)))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	if len(word) != 0 {
+		words = append(words, strings.ToLower(string(word)))
+	}
+	return words
+}
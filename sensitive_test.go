@@ -0,0 +1,70 @@
+package config_exporter
+
+import (
+	"bytes"
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type SensitiveComponent struct {
+	Password string `prop:"db.password:p@ss"`
+	APIKey   string `prop:"db.apiKey:abc"`
+	Cert     string `prop:"tls.cert:pem,sensitive"`
+	Monkey   string `prop:"zoo.monkey:george"`
+}
+
+func TestSensitive(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&SensitiveComponent{}, exporter),
+	)
+	assert.NoError(t, err)
+
+	t.Run("Masked", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, exporter.Export(buf, FormatDotenv, 0))
+		assert.Equal(t, `DB_APIKEY=******
+DB_PASSWORD=******
+TLS_CERT=******
+ZOO_MONKEY=george
+`, buf.String())
+	})
+	t.Run("Reveal", func(t *testing.T) {
+		bytes, err := yaml.Marshal(exporter.GetConfig(RevealSensitive))
+		assert.NoError(t, err)
+		assert.Equal(t, `db:
+    apiKey: abc
+    password: p@ss
+tls:
+    cert: pem
+zoo:
+    monkey: george
+`, string(bytes))
+	})
+	t.Run("Describe", func(t *testing.T) {
+		entry := findEntry(exporter.Describe(), "db.password")
+		assert.NotNil(t, entry)
+		assert.True(t, entry.Sensitive)
+		assert.Equal(t, MaskedValue, entry.Default)
+		assert.Nil(t, exporter.JSONSchema().Properties["db"].Properties["password"].Default)
+	})
+}
+
+func TestIsSensitiveKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"db.password":        true,
+		"auth.clientSecret":  true,
+		"github.token":       true,
+		"api.key":            true,
+		"api.private_key":    true,
+		"api.APIKey":         true,
+		"zoo.monkey":         false,
+		"cassandra.keyspace": false,
+	} {
+		assert.Equal(t, want, isSensitiveKey(key), key)
+	}
+}
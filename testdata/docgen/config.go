@@ -0,0 +1,37 @@
+package docgen
+
+// CacheConfig is bound to the "cache" prefix.
+type CacheConfig struct {
+	// TTL is the time to live of an entry,
+	// in seconds.
+	TTL  int `yaml:"ttl"`
+	Size int `yaml:"size"` // max entries kept in memory
+	// Name has a desc tag, which wins over the comment.
+	Name string `yaml:"name" desc:"cache name"`
+	// Sub is not described by its own type.
+	Sub     SubConfig `yaml:"sub"`
+	Ignored string    `yaml:"-"` // never bound
+	hidden  string
+}
+
+func (c *CacheConfig) Prefix() string {
+	return "cache"
+}
+
+type SubConfig struct {
+	// Key of the sub config.
+	Key string
+}
+
+type Described struct {
+	// Level is already described.
+	Level int
+}
+
+func (d *Described) Prefix() string {
+	return "described"
+}
+
+func (d *Described) ConfigDescription() map[string]string {
+	return nil
+}
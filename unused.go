@@ -0,0 +1,114 @@
+package config_exporter
+
+import (
+	"fmt"
+	"github.com/go-kid/properties"
+	"sort"
+	"strings"
+)
+
+const maxSuggestions = 3
+
+type UnusedKey struct {
+	Key         string
+	Suggestions []string
+}
+
+func (k UnusedKey) String() string {
+	if len(k.Suggestions) == 0 {
+		return k.Key
+	}
+	return fmt.Sprintf("%s (did you mean %s?)", k.Key, strings.Join(k.Suggestions, ", "))
+}
+
+// UnusedKeys reports the keys of the loaded configuration that no component
+// binds, suggesting bound keys within a small edit distance.
+func (d *postProcessor) UnusedKeys() []UnusedKey {
+	if d.configure == nil {
+		return nil
+	}
+	loaded, ok := d.configure.Get("").(map[string]any)
+	if !ok {
+		return nil
+	}
+	var known []string
+	for _, entry := range d.Describe() {
+		known = append(known, entry.Key)
+	}
+
+	var unused []UnusedKey
+	for key := range properties.Properties(loaded).ToPropertiesMap() {
+		if isBound(key, known) {
+			continue
+		}
+		unused = append(unused, UnusedKey{
+			Key:         key,
+			Suggestions: suggest(key, known),
+		})
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].Key < unused[j].Key
+	})
+	return unused
+}
+
+func isBound(key string, known []string) bool {
+	for _, k := range known {
+		if strings.EqualFold(key, k) ||
+			len(key) > len(k) && key[len(k)] == '.' && strings.EqualFold(key[:len(k)], k) {
+			return true
+		}
+	}
+	return false
+}
+
+func suggest(key string, known []string) []string {
+	type candidate struct {
+		key  string
+		dist int
+	}
+	var (
+		candidates []candidate
+		maxDist    = len(key) / 5
+	)
+	if maxDist < 2 {
+		maxDist = 2
+	}
+	for _, k := range known {
+		if dist := levenshtein(strings.ToLower(key), strings.ToLower(k)); dist <= maxDist {
+			candidates = append(candidates, candidate{key: k, dist: dist})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].key < candidates[j].key
+	})
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].key)
+	}
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,39 @@
+package config_exporter
+
+import (
+	"github.com/go-kid/ioc"
+	"github.com/go-kid/ioc/app"
+	"github.com/go-kid/ioc/configure/loader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnusedKeys(t *testing.T) {
+	exporter := NewConfigExporter()
+	_, err := ioc.Run(
+		app.LogError,
+		app.SetComponents(&A{}, exporter),
+		app.AddConfigLoader(loader.NewRawLoader([]byte(`
+app:
+    configA: a
+    confgA: typo
+    unknown: true
+Demo:
+    A: a
+    Aa: typo
+    M:
+        any: 1
+PartialZeroMap:
+    sub1:
+        sub: sub1
+`))),
+	)
+	assert.NoError(t, err)
+	unused := exporter.UnusedKeys()
+	assert.Equal(t, []UnusedKey{
+		{Key: "app.confga", Suggestions: []string{"app.configA", "app.configB"}},
+		{Key: "app.unknown"},
+		{Key: "demo.aa", Suggestions: []string{"Demo.A", "Demo.B", "Demo.M"}},
+	}, unused)
+	assert.Equal(t, "app.confga (did you mean app.configA, app.configB?)", unused[0].String())
+}